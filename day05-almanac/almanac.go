@@ -2,31 +2,32 @@ package almanac
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"math"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/harveysanders/advent-of-code-2023/day05-almanac/category"
 )
 
 type Almanac struct {
-	Seeds []int
-	Maps  map[category.Name]Conversion
+	Seeds        []int
+	SeedCategory category.Name // Category of the seed values, parsed from the header, e.g. "seeds:" -> "seed".
+	Maps         map[category.Name]Conversion
 }
 
 type Conversion struct {
-	Src    category.Name
-	Dst    category.Name
-	Ranges []Range
+	Src    category.Name `json:"src" yaml:"src"`
+	Dst    category.Name `json:"dst" yaml:"dst"`
+	Ranges []Range       `json:"ranges" yaml:"ranges"`
 }
 
 type Range struct {
-	SrcStart int
-	DstStart int
-	Length   int
+	SrcStart int `json:"srcStart" yaml:"srcStart"`
+	DstStart int `json:"dstStart" yaml:"dstStart"`
+	Length   int `json:"length" yaml:"length"`
 }
 
 func (c Conversion) convert(src category.Name, srcVal int) (dst category.Name, result int) {
@@ -40,12 +41,18 @@ func (c Conversion) convert(src category.Name, srcVal int) (dst category.Name, r
 }
 
 func (a Almanac) ConvertTo(src category.Name, dest category.Name, val int) (int, error) {
+	if src == dest {
+		return val, nil
+	}
 	converter, ok := a.Maps[src]
 	if !ok {
 		return 0, fmt.Errorf("converter not found for %q", src)
 	}
 	nextDst, nextVal := converter.convert(src, val)
-	for dest != category.Name(nextDst) {
+	for steps := 1; dest != category.Name(nextDst); steps++ {
+		if steps > len(a.Maps) {
+			return 0, fmt.Errorf("%q not reachable from %q", dest, src)
+		}
 		nextSrc := converter.Dst
 		converter, ok = a.Maps[nextSrc]
 		if !ok {
@@ -56,11 +63,74 @@ func (a Almanac) ConvertTo(src category.Name, dest category.Name, val int) (int,
 	return nextVal, nil
 }
 
-func (a Almanac) LowestLocation(useRange bool) (int, error) {
+// Categories returns the almanac's categories, discovered from the seed header and the conversion map headers,
+// in conversion order.
+func (a Almanac) Categories() *category.Registry {
+	r := category.NewRegistry()
+	if start := a.startCategory(); start != "" {
+		r.Register(start)
+	}
+	for _, src := range a.mapOrder() {
+		r.Register(src)
+		r.Register(a.Maps[src].Dst)
+	}
+	return r
+}
+
+// startCategory returns the seed category, falling back to the start of the first conversion chain.
+func (a Almanac) startCategory() category.Name {
+	if a.SeedCategory != "" {
+		return a.SeedCategory
+	}
+	if order := a.mapOrder(); len(order) > 0 {
+		return order[0]
+	}
+	return ""
+}
+
+// endCategory returns the last category reached by following the conversions from the seed category.
+func (a Almanac) endCategory() category.Name {
+	end := a.startCategory()
+	for i := 0; i < len(a.Maps); i++ {
+		c, ok := a.Maps[end]
+		if !ok {
+			break
+		}
+		end = c.Dst
+	}
+	return end
+}
+
+// resolveCategories fills in the default start and end categories and checks both exist in the almanac.
+func (a Almanac) resolveCategories(from, to category.Name) (category.Name, category.Name, error) {
+	if from == "" {
+		from = a.startCategory()
+	}
+	if to == "" {
+		to = a.endCategory()
+	}
+	categories := a.Categories()
+	for _, c := range []category.Name{from, to} {
+		if !categories.Contains(c) {
+			return from, to, fmt.Errorf("unknown category: %q", c)
+		}
+	}
+	return from, to, nil
+}
+
+// LowestLocation converts each seed from the "from" category to the "to" category and returns the lowest result.
+// An empty from defaults to the seed category, and an empty to defaults to the last category in the conversion chain.
+// If useRange is true, the seeds are treated as (start, length) pairs.
+func (a Almanac) LowestLocation(useRange bool, from, to category.Name) (int, error) {
+	from, to, err := a.resolveCategories(from, to)
+	if err != nil {
+		return 0, err
+	}
+
 	lowest := math.MaxFloat64
 	if !useRange {
 		for _, seed := range a.Seeds {
-			location, err := a.ConvertTo(category.Seed, category.Location, seed)
+			location, err := a.ConvertTo(from, to, seed)
 			if err != nil {
 				return 0, err
 			}
@@ -70,28 +140,11 @@ func (a Almanac) LowestLocation(useRange bool) (int, error) {
 	}
 
 	// Part 2 range mode
-	for i := 0; i < len(a.Seeds); i += 2 {
-		startTime := time.Now()
-
-		start := a.Seeds[i]
-		count := a.Seeds[i+1]
-		for seed := start; seed < start+count; seed++ {
-			if seed%int(math.Pow10(6)) == 0 {
-				fmt.Println(".")
-			}
-			location, err := a.ConvertTo(category.Seed, category.Location, seed)
-			if err != nil {
-				return 0, err
-			}
-			lowest = math.Min(lowest, float64(location))
-		}
-
-		fmt.Printf("loop: %d, elapsed(s): %d, lowest: %d\n",
-			i,
-			int(time.Since(startTime).Seconds()),
-			int(lowest))
+	lowestInRange, _, err := a.LowestLocationParallel(context.Background(), SearchOptions{From: from, To: to})
+	if err != nil {
+		return 0, err
 	}
-	return int(lowest), nil
+	return lowestInRange, nil
 }
 
 func Parse(r io.Reader) (Almanac, error) {
@@ -110,7 +163,11 @@ func Parse(r io.Reader) (Almanac, error) {
 
 		if isHeader {
 			isHeader = false
-			parts := strings.TrimPrefix(line, "seeds: ")
+			name, parts, ok := strings.Cut(line, ":")
+			if !ok {
+				return a, fmt.Errorf("invalid seeds header: %q", line)
+			}
+			a.SeedCategory = category.Name(strings.TrimSuffix(strings.TrimSpace(name), "s"))
 			nums := strings.Fields(parts)
 			for _, v := range nums {
 				n, err := strconv.Atoi(v)
@@ -270,9 +270,57 @@ humidity-to-location map:
 			a, err := almanac.Parse(tc.input)
 			require.NoError(t, err)
 
-			got, err := a.LowestLocation(tc.isPart2)
+			got, err := a.LowestLocation(tc.isPart2, category.Seed, category.Location)
 			require.NoError(t, err)
 			require.Equal(t, tc.want, got)
 		})
 	}
 }
+
+func TestParseCategories(t *testing.T) {
+	input := strings.NewReader(`bulbs: 1 10
+
+bulb-to-soil map:
+0 5 5
+
+soil-to-weather map:
+100 0 10
+
+weather-to-location map:
+7 100 3
+`)
+
+	a, err := almanac.Parse(input)
+	require.NoError(t, err)
+	require.Equal(t, category.Name("bulb"), a.SeedCategory)
+
+	wantCategories := []category.Name{"bulb", "soil", "weather", "location"}
+	require.Equal(t, wantCategories, a.Categories().Names())
+
+	testCases := []struct {
+		name     string
+		from, to category.Name
+		useRange bool
+		want     int
+	}{
+		{name: "default categories", want: 8},
+		{name: "bulb to weather", from: "bulb", to: "weather", want: 10},
+		{name: "bulb to soil", from: "bulb", to: "soil", want: 1},
+		{name: "same category", from: "weather", to: "weather", want: 1},
+		{name: "range, default categories", useRange: true, want: 7},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := a.LowestLocation(tc.useRange, tc.from, tc.to)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err = a.LowestLocation(false, "bulb", "humidity")
+	require.Error(t, err)
+
+	_, err = a.LowestLocation(false, "location", "bulb")
+	require.Error(t, err)
+}
@@ -2,6 +2,8 @@ package category
 
 type Name string
 
+// Well-known category names from the puzzle input. Almanacs are not limited to these;
+// categories are discovered from the map headers when parsing.
 const (
 	Fertilizer  Name = "fertilizer"
 	Humidity    Name = "humidity"
@@ -12,3 +14,44 @@ const (
 	Temperature Name = "temperature"
 	Water       Name = "water"
 )
+
+// Registry is an ordered set of category names, kept in the order they were registered.
+type Registry struct {
+	names []Name
+	seen  map[Name]struct{}
+}
+
+func NewRegistry(names ...Name) *Registry {
+	r := &Registry{
+		names: make([]Name, 0, len(names)),
+		seen:  make(map[Name]struct{}, len(names)),
+	}
+	for _, n := range names {
+		r.Register(n)
+	}
+	return r
+}
+
+// Register adds the name to the registry, returning false if it was already registered.
+func (r *Registry) Register(n Name) bool {
+	if _, ok := r.seen[n]; ok {
+		return false
+	}
+	r.seen[n] = struct{}{}
+	r.names = append(r.names, n)
+	return true
+}
+
+func (r *Registry) Contains(n Name) bool {
+	_, ok := r.seen[n]
+	return ok
+}
+
+// Names returns the registered names in registration order.
+func (r *Registry) Names() []Name {
+	return append([]Name{}, r.names...)
+}
+
+func (r *Registry) Len() int {
+	return len(r.names)
+}
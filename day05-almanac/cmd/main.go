@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
 
 	almanac "github.com/harveysanders/advent-of-code-2023/day05-almanac"
 	"github.com/harveysanders/advent-of-code-2023/internal/github"
@@ -21,10 +24,14 @@ func main() {
 		log.Fatal(err)
 	}
 
-	lowest, err := a.LowestLocation(true)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	lowest, stats, err := a.LowestLocationParallel(ctx, almanac.SearchOptions{})
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	log.Printf("checked %d seeds in %s (%.0f seeds/s)\n", stats.Seeds, stats.Elapsed, stats.SeedsPerSecond())
 	fmt.Println(lowest)
 }
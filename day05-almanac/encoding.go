@@ -0,0 +1,166 @@
+package almanac
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/harveysanders/advent-of-code-2023/day05-almanac/category"
+	"gopkg.in/yaml.v3"
+)
+
+// document is the JSON and YAML representation of an Almanac.
+// Maps are stored as a list in chain order instead of keyed by source category.
+type document struct {
+	SeedCategory category.Name `json:"seedCategory,omitempty" yaml:"seedCategory,omitempty"`
+	Seeds        []int         `json:"seeds" yaml:"seeds"`
+	Maps         []Conversion  `json:"maps" yaml:"maps"`
+}
+
+// MarshalText encodes the almanac in the puzzle input format. Conversion maps are written in chain order,
+// starting from any source category that is not the destination of another map.
+//
+// Ex:
+//
+//	seeds: 79 14
+//
+//	seed-to-soil map:
+//	50 98 2
+func (a Almanac) MarshalText() ([]byte, error) {
+	var b bytes.Buffer
+	seeds := make([]string, len(a.Seeds))
+	for i, s := range a.Seeds {
+		seeds[i] = strconv.Itoa(s)
+	}
+	seedCategory := a.startCategory()
+	if seedCategory == "" {
+		seedCategory = category.Seed
+	}
+	fmt.Fprintf(&b, "%ss: %s\n", seedCategory, strings.Join(seeds, " "))
+
+	for _, src := range a.mapOrder() {
+		c := a.Maps[src]
+		if c.Src == "" || c.Dst == "" {
+			return nil, fmt.Errorf("conversion %q: missing source or destination category", src)
+		}
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "%s-to-%s map:\n", c.Src, c.Dst)
+		for _, r := range c.Ranges {
+			fmt.Fprintf(&b, "%d %d %d\n", r.DstStart, r.SrcStart, r.Length)
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// UnmarshalText decodes an almanac from the puzzle input format.
+func (a *Almanac) UnmarshalText(text []byte) error {
+	parsed, err := Parse(bytes.NewReader(text))
+	if err != nil {
+		return fmt.Errorf("Parse(): %w", err)
+	}
+	*a = parsed
+	return nil
+}
+
+func (a Almanac) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.document())
+}
+
+func (a *Almanac) UnmarshalJSON(data []byte) error {
+	var doc document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	return a.fromDocument(doc)
+}
+
+func (a Almanac) MarshalYAML() (interface{}, error) {
+	return a.document(), nil
+}
+
+func (a *Almanac) UnmarshalYAML(value *yaml.Node) error {
+	var doc document
+	if err := value.Decode(&doc); err != nil {
+		return err
+	}
+	return a.fromDocument(doc)
+}
+
+func (a Almanac) document() document {
+	doc := document{
+		SeedCategory: a.SeedCategory,
+		Seeds:        a.Seeds,
+		Maps:         make([]Conversion, 0, len(a.Maps)),
+	}
+	if doc.Seeds == nil {
+		doc.Seeds = []int{}
+	}
+	for _, src := range a.mapOrder() {
+		doc.Maps = append(doc.Maps, a.Maps[src])
+	}
+	return doc
+}
+
+func (a *Almanac) fromDocument(doc document) error {
+	parsed := Almanac{
+		Seeds:        doc.Seeds,
+		SeedCategory: doc.SeedCategory,
+		Maps:         make(map[category.Name]Conversion, len(doc.Maps)),
+	}
+	if parsed.Seeds == nil {
+		parsed.Seeds = make([]int, 0)
+	}
+	for _, c := range doc.Maps {
+		if _, ok := parsed.Maps[c.Src]; ok {
+			return fmt.Errorf("duplicate conversion for %q", c.Src)
+		}
+		if c.Ranges == nil {
+			c.Ranges = make([]Range, 0)
+		}
+		parsed.Maps[c.Src] = c
+	}
+	*a = parsed
+	return nil
+}
+
+// mapOrder returns the source categories of the almanac's maps, ordered by following each chain of conversions.
+// Maps that are not reachable from a chain start (for example, maps forming a cycle) are appended in name order.
+func (a Almanac) mapOrder() []category.Name {
+	isDst := make(map[category.Name]bool, len(a.Maps))
+	for _, c := range a.Maps {
+		isDst[c.Dst] = true
+	}
+
+	names := make([]category.Name, 0, len(a.Maps))
+	for src := range a.Maps {
+		names = append(names, src)
+	}
+	slices.Sort(names)
+
+	order := make([]category.Name, 0, len(a.Maps))
+	visited := make(map[category.Name]bool, len(a.Maps))
+	follow := func(src category.Name) {
+		for {
+			c, ok := a.Maps[src]
+			if !ok || visited[src] {
+				return
+			}
+			visited[src] = true
+			order = append(order, src)
+			src = c.Dst
+		}
+	}
+
+	for _, src := range names {
+		if !isDst[src] {
+			follow(src)
+		}
+	}
+	for _, src := range names {
+		follow(src)
+	}
+	return order
+}
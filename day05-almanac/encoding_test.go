@@ -0,0 +1,132 @@
+package almanac_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	almanac "github.com/harveysanders/advent-of-code-2023/day05-almanac"
+	category "github.com/harveysanders/advent-of-code-2023/day05-almanac/category"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const sampleAlmanac = `seeds: 79 14 55 13
+
+seed-to-soil map:
+50 98 2
+52 50 48
+
+soil-to-fertilizer map:
+0 15 37
+37 52 2
+39 0 15
+
+fertilizer-to-water map:
+49 53 8
+0 11 42
+42 0 7
+57 7 4
+
+water-to-light map:
+88 18 7
+18 25 70
+
+light-to-temperature map:
+45 77 23
+81 45 19
+68 64 13
+
+temperature-to-humidity map:
+0 69 1
+1 0 69
+
+humidity-to-location map:
+60 56 37
+56 93 4
+`
+
+func TestMarshalText(t *testing.T) {
+	a, err := almanac.Parse(strings.NewReader(sampleAlmanac))
+	require.NoError(t, err)
+
+	got, err := a.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, sampleAlmanac, string(got))
+
+	var decoded almanac.Almanac
+	err = decoded.UnmarshalText(got)
+	require.NoError(t, err)
+	require.Equal(t, a, decoded)
+}
+
+func TestMarshalTextProgrammatic(t *testing.T) {
+	a := almanac.Almanac{
+		Seeds: []int{1, 2},
+		Maps: map[category.Name]almanac.Conversion{
+			category.Soil: {
+				Src:    category.Soil,
+				Dst:    category.Water,
+				Ranges: []almanac.Range{{DstStart: 7, SrcStart: 8, Length: 9}},
+			},
+			category.Seed: {
+				Src:    category.Seed,
+				Dst:    category.Soil,
+				Ranges: []almanac.Range{{DstStart: 4, SrcStart: 5, Length: 6}},
+			},
+		},
+	}
+
+	got, err := a.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, `seeds: 1 2
+
+seed-to-soil map:
+4 5 6
+
+soil-to-water map:
+7 8 9
+`, string(got))
+}
+
+func TestMarshalJSON(t *testing.T) {
+	a, err := almanac.Parse(strings.NewReader(sampleAlmanac))
+	require.NoError(t, err)
+
+	data, err := json.Marshal(a)
+	require.NoError(t, err)
+
+	var decoded almanac.Almanac
+	err = json.Unmarshal(data, &decoded)
+	require.NoError(t, err)
+	require.Equal(t, a, decoded)
+
+	text, err := decoded.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, sampleAlmanac, string(text))
+
+	var c almanac.Conversion
+	err = json.Unmarshal([]byte(`{"src":"seed","dst":"soil","ranges":[{"srcStart":98,"dstStart":50,"length":2}]}`), &c)
+	require.NoError(t, err)
+	require.Equal(t, a.Maps[category.Seed].Ranges[0], c.Ranges[0])
+}
+
+func TestMarshalYAML(t *testing.T) {
+	a, err := almanac.Parse(strings.NewReader(sampleAlmanac))
+	require.NoError(t, err)
+
+	data, err := yaml.Marshal(a)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "seedCategory: seed\n")
+
+	var decoded almanac.Almanac
+	err = yaml.Unmarshal(data, &decoded)
+	require.NoError(t, err)
+	require.Equal(t, a, decoded)
+}
+
+func TestUnmarshalJSONDuplicateMap(t *testing.T) {
+	var a almanac.Almanac
+	err := json.Unmarshal([]byte(`{"seeds":[1],"maps":[{"src":"seed","dst":"soil"},{"src":"seed","dst":"water"}]}`), &a)
+	require.Error(t, err)
+}
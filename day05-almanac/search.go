@@ -0,0 +1,160 @@
+package almanac
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/harveysanders/advent-of-code-2023/day05-almanac/category"
+)
+
+const defaultChunkSize = 1 << 20
+
+// SearchOptions configures the brute-force seed range search.
+type SearchOptions struct {
+	Workers   int // Number of goroutines checking seeds. Defaults to runtime.NumCPU().
+	ChunkSize int // Number of seeds handed to a worker at a time. Defaults to 1<<20.
+
+	From category.Name // Category to convert from. Defaults to the almanac's seed category.
+	To   category.Name // Category to convert to. Defaults to the last category in the conversion chain.
+}
+
+// SearchStats reports how much work a search did.
+type SearchStats struct {
+	Seeds   int           // Number of seeds converted.
+	Chunks  int           // Number of chunks processed.
+	Elapsed time.Duration // Wall time of the search.
+}
+
+// SeedsPerSecond returns the search throughput.
+func (s SearchStats) SeedsPerSecond() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+	return float64(s.Seeds) / s.Elapsed.Seconds()
+}
+
+type seedChunk struct {
+	start int
+	count int
+}
+
+type searchResult struct {
+	lowest int
+	seeds  int
+	chunks int
+}
+
+// LowestLocationParallel treats the almanac's seeds as (start, length) pairs and converts every seed in every range
+// to find the lowest value in the destination category. The ranges are split into chunks which are checked by a bounded pool of workers,
+// each keeping its own minimum. The search stops early with the context's error if ctx is cancelled, or with the
+// first conversion error if a worker fails.
+func (a Almanac) LowestLocationParallel(ctx context.Context, opts SearchOptions) (int, SearchStats, error) {
+	startTime := time.Now()
+	stats := SearchStats{}
+	fail := func(err error) (int, SearchStats, error) {
+		stats.Elapsed = time.Since(startTime)
+		return 0, stats, err
+	}
+	if len(a.Seeds)%2 != 0 {
+		return fail(fmt.Errorf("seed ranges must be (start, length) pairs, got %d values", len(a.Seeds)))
+	}
+
+	from, to, err := a.resolveCategories(opts.From, opts.To)
+	if err != nil {
+		return fail(err)
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.NumCPU()
+	}
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = defaultChunkSize
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chunks := make(chan seedChunk)
+	go func() {
+		defer close(chunks)
+		for i := 0; i < len(a.Seeds); i += 2 {
+			start, count := a.Seeds[i], a.Seeds[i+1]
+			for offset := 0; offset < count; offset += opts.ChunkSize {
+				c := seedChunk{start: start + offset, count: min(opts.ChunkSize, count-offset)}
+				select {
+				case chunks <- c:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	// The first worker to fail cancels the others, which then stop with context errors.
+	// Only keep the error that caused the cancellation.
+	var workerErr error
+	var errOnce sync.Once
+	results := make([]searchResult, opts.Workers)
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Workers; w++ {
+		wg.Add(1)
+		go func(res *searchResult) {
+			defer wg.Done()
+			res.lowest = math.MaxInt
+			for c := range chunks {
+				lowest, err := a.lowestInChunk(ctx, c, from, to)
+				if err != nil {
+					if !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+						errOnce.Do(func() { workerErr = err })
+					}
+					cancel()
+					return
+				}
+				res.lowest = min(res.lowest, lowest)
+				res.seeds += c.count
+				res.chunks++
+			}
+		}(&results[w])
+	}
+	wg.Wait()
+
+	lowest := math.MaxInt
+	for _, res := range results {
+		lowest = min(lowest, res.lowest)
+		stats.Seeds += res.seeds
+		stats.Chunks += res.chunks
+	}
+	if workerErr != nil {
+		return fail(workerErr)
+	}
+	if err := ctx.Err(); err != nil {
+		return fail(err)
+	}
+	if stats.Seeds == 0 {
+		return fail(fmt.Errorf("no seeds to search"))
+	}
+	stats.Elapsed = time.Since(startTime)
+	return lowest, stats, nil
+}
+
+// lowestInChunk converts each seed in the chunk, checking for cancellation periodically.
+func (a Almanac) lowestInChunk(ctx context.Context, c seedChunk, from, to category.Name) (int, error) {
+	lowest := math.MaxInt
+	for seed := c.start; seed < c.start+c.count; seed++ {
+		if (seed-c.start)%(1<<16) == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+		}
+		location, err := a.ConvertTo(from, to, seed)
+		if err != nil {
+			return 0, err
+		}
+		lowest = min(lowest, location)
+	}
+	return lowest, nil
+}
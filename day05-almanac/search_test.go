@@ -0,0 +1,95 @@
+package almanac_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	almanac "github.com/harveysanders/advent-of-code-2023/day05-almanac"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLowestLocationParallel(t *testing.T) {
+	a, err := almanac.Parse(strings.NewReader(sampleAlmanac))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		opts almanac.SearchOptions
+	}{
+		{name: "defaults", opts: almanac.SearchOptions{}},
+		{name: "single worker", opts: almanac.SearchOptions{Workers: 1, ChunkSize: 5}},
+		{name: "more workers than chunks", opts: almanac.SearchOptions{Workers: 16, ChunkSize: 3}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, stats, err := a.LowestLocationParallel(context.Background(), tc.opts)
+			require.NoError(t, err)
+			require.Equal(t, 46, got)
+			require.Equal(t, 14+13, stats.Seeds)
+		})
+	}
+}
+
+func TestLowestLocationParallelMatchesSequential(t *testing.T) {
+	a, err := almanac.Parse(strings.NewReader(sampleAlmanac))
+	require.NoError(t, err)
+	a.Seeds = []int{0, 200, 37, 5}
+
+	want := -1
+	for i := 0; i < len(a.Seeds); i += 2 {
+		for seed := a.Seeds[i]; seed < a.Seeds[i]+a.Seeds[i+1]; seed++ {
+			loc, err := a.ConvertTo("seed", "location", seed)
+			require.NoError(t, err)
+			if want == -1 || loc < want {
+				want = loc
+			}
+		}
+	}
+
+	got, stats, err := a.LowestLocationParallel(context.Background(), almanac.SearchOptions{Workers: 3, ChunkSize: 7})
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+	require.Equal(t, 205, stats.Seeds)
+	require.Equal(t, 29+1, stats.Chunks)
+}
+
+func TestLowestLocationParallelCancel(t *testing.T) {
+	a, err := almanac.Parse(strings.NewReader(sampleAlmanac))
+	require.NoError(t, err)
+	a.Seeds = []int{0, 1 << 40}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err = a.LowestLocationParallel(ctx, almanac.SearchOptions{})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestLowestLocationParallelInvalidSeeds(t *testing.T) {
+	a, err := almanac.Parse(strings.NewReader(sampleAlmanac))
+	require.NoError(t, err)
+	a.Seeds = []int{1, 2, 3}
+
+	_, _, err = a.LowestLocationParallel(context.Background(), almanac.SearchOptions{})
+	require.Error(t, err)
+}
+
+func TestLowestLocationParallelConversionError(t *testing.T) {
+	a, err := almanac.Parse(strings.NewReader(sampleAlmanac))
+	require.NoError(t, err)
+	a.Seeds = []int{0, 1000}
+
+	// Both categories exist, but there is no map out of "location", so every conversion fails.
+	// The workers that are cancelled by the first failure must not hide it.
+	_, stats, err := a.LowestLocationParallel(context.Background(), almanac.SearchOptions{
+		Workers:   8,
+		ChunkSize: 10,
+		From:      "location",
+		To:        "seed",
+	})
+	require.ErrorContains(t, err, `converter not found for "location"`)
+	require.NotErrorIs(t, err, context.Canceled)
+	require.Positive(t, stats.Elapsed)
+}
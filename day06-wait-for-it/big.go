@@ -0,0 +1,106 @@
+package race
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// BigRace is a Race with arbitrary-precision time and distance, for inputs too large for int.
+type BigRace struct {
+	Time     *big.Int // Time alloted for race in milliseconds.
+	Distance *big.Int // Record distance a boat has traveled for the given race time, in millimeters.
+}
+
+type BigRaces []BigRace
+
+// Big converts the race to a BigRace.
+func (r Race) Big() BigRace {
+	return BigRace{
+		Time:     big.NewInt(int64(r.Time)),
+		Distance: big.NewInt(int64(r.Distance)),
+	}
+}
+
+// WinningRange returns the shortest and longest charge button durations that beat the race record distance.
+// See Race.WinningRange. ok is false if no duration wins.
+func (r BigRace) WinningRange() (first, last *big.Int, ok bool) {
+	// disc = Time^2 - 4*Distance
+	disc := new(big.Int).Mul(r.Time, r.Time)
+	disc.Sub(disc, new(big.Int).Lsh(r.Distance, 2))
+	if disc.Sign() < 0 {
+		return nil, nil, false
+	}
+
+	// Using floor(sqrt(disc)) puts the estimate at most one step below the exact lower boundary.
+	one := big.NewInt(1)
+	mid := new(big.Int).Rsh(r.Time, 1)
+	first = new(big.Int).Sub(r.Time, new(big.Int).Sqrt(disc))
+	first.Rsh(first, 1)
+	if first.Cmp(mid) > 0 {
+		first.Set(mid)
+	}
+	for first.Sign() > 0 && r.beats(new(big.Int).Sub(first, one)) {
+		first.Sub(first, one)
+	}
+	for first.Cmp(mid) <= 0 && !r.beats(first) {
+		first.Add(first, one)
+	}
+	if first.Cmp(mid) > 0 {
+		return nil, nil, false
+	}
+
+	// Distance is symmetric around Time/2.
+	return first, new(big.Int).Sub(r.Time, first), true
+}
+
+// WaysToWin returns the number of charge button durations that beat the race record distance.
+func (r BigRace) WaysToWin() *big.Int {
+	first, last, ok := r.WinningRange()
+	if !ok {
+		return new(big.Int)
+	}
+	n := new(big.Int).Sub(last, first)
+	return n.Add(n, big.NewInt(1))
+}
+
+// beats reports whether holding the button for hold ms beats the record distance.
+func (r BigRace) beats(hold *big.Int) bool {
+	dist := new(big.Int).Sub(r.Time, hold)
+	dist.Mul(dist, hold)
+	return dist.Cmp(r.Distance) > 0
+}
+
+func (r BigRaces) MarginOfError() *big.Int {
+	res := big.NewInt(1)
+	for _, race := range r {
+		res.Mul(res, race.WaysToWin())
+	}
+	return res
+}
+
+// ParseBig reads the race times and record distances as arbitrary-precision numbers.
+// If mergeColumns is true, the columns are joined into a single race.
+func ParseBig(data io.Reader, mergeColumns bool) (BigRaces, error) {
+	rawTimes, rawDists, err := parseColumns(data, mergeColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	races := make(BigRaces, len(rawTimes))
+	for i, v := range rawTimes {
+		ms, ok := new(big.Int).SetString(v, 10)
+		if !ok {
+			return races, fmt.Errorf("invalid time: %q", v)
+		}
+		races[i] = BigRace{Time: ms, Distance: new(big.Int)}
+	}
+	for i, v := range rawDists {
+		d, ok := new(big.Int).SetString(v, 10)
+		if !ok {
+			return races, fmt.Errorf("invalid distance: %q", v)
+		}
+		races[i].Distance = d
+	}
+	return races, nil
+}
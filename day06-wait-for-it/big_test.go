@@ -0,0 +1,117 @@
+package race_test
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	race "github.com/harveysanders/advent-of-code-2023/day06-wait-for-it"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOverflow(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+	}{
+		{
+			name: "distance too large for int",
+			input: `Time:      7  15   30
+Distance:  9  40  99999999999999999999
+`,
+		},
+		{
+			name: "time squared overflows int",
+			input: `Time:      7  15   9999999999
+Distance:  9  40  200
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := race.Parse(strings.NewReader(tc.input), true)
+			require.ErrorIs(t, err, race.ErrOverflow)
+		})
+	}
+}
+
+func TestParseBig(t *testing.T) {
+	sample := `Time:      7  15   30
+Distance:  9  40  200
+`
+	races, err := race.ParseBig(strings.NewReader(sample), false)
+	require.NoError(t, err)
+	require.Len(t, races, 3)
+	require.Equal(t, "288", races.MarginOfError().String())
+
+	races, err = race.ParseBig(strings.NewReader(sample), true)
+	require.NoError(t, err)
+	require.Len(t, races, 1)
+	require.Equal(t, "71503", races.MarginOfError().String())
+
+	_, err = race.ParseBig(strings.NewReader("Time: 7x\nDistance: 9\n"), false)
+	require.Error(t, err)
+}
+
+func TestBigWinningRange(t *testing.T) {
+	t.Run("matches int solver", func(t *testing.T) {
+		for time := 0; time <= 40; time++ {
+			for dist := 0; dist <= time*time/4+1; dist++ {
+				r := race.Race{Time: time, Distance: dist}
+				wantFirst, wantLast, wantOK := r.WinningRange()
+
+				first, last, ok := r.Big().WinningRange()
+				require.Equalf(t, wantOK, ok, "race: %+v", r)
+				if ok {
+					require.Equalf(t, int64(wantFirst), first.Int64(), "race: %+v", r)
+					require.Equalf(t, int64(wantLast), last.Int64(), "race: %+v", r)
+				}
+			}
+		}
+	})
+
+	testCases := []struct {
+		time      string
+		distance  string
+		wantFirst string
+		wantLast  string
+	}{
+		{
+			// Roots are 10^20 and 10^20 + 2, so only the midpoint wins.
+			time:      "200000000000000000002",
+			distance:  "10000000000000000000200000000000000000000",
+			wantFirst: "100000000000000000001",
+			wantLast:  "100000000000000000001",
+		},
+		{
+			time:      "123456789012345678901234567890",
+			distance:  "1",
+			wantFirst: "1",
+			wantLast:  "123456789012345678901234567889",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("time: %s ms, record: %s mm", tc.time, tc.distance), func(t *testing.T) {
+			time, _ := new(big.Int).SetString(tc.time, 10)
+			dist, _ := new(big.Int).SetString(tc.distance, 10)
+			r := race.BigRace{Time: time, Distance: dist}
+
+			first, last, ok := r.WinningRange()
+			require.True(t, ok)
+			require.Equal(t, tc.wantFirst, first.String())
+			require.Equal(t, tc.wantLast, last.String())
+		})
+	}
+
+	t.Run("no winner", func(t *testing.T) {
+		time, _ := new(big.Int).SetString("200000000000000000000", 10)
+		dist, _ := new(big.Int).SetString("10000000000000000000000000000000000000000", 10)
+		r := race.BigRace{Time: time, Distance: dist}
+		_, _, ok := r.WinningRange()
+		require.False(t, ok)
+		require.Equal(t, "0", r.WaysToWin().String())
+	})
+}
@@ -0,0 +1,182 @@
+package race
+
+import "math"
+
+// Model calculates how far a boat travels when the charge button is held for hold ms of a race lasting total ms.
+type Model interface {
+	Distance(hold, total float64) float64
+}
+
+// ClosedForm is implemented by models that can solve for their winning hold times directly.
+type ClosedForm interface {
+	WinningRange(r Race) (first, last int, ok bool)
+}
+
+// Unimodal is implemented by models whose distance rises to a single peak and then falls as the hold time increases.
+// Winning hold times for these models form a single interval, which can be found with a monotonic search.
+type Unimodal interface {
+	Unimodal() bool
+}
+
+// Linear is the puzzle's model: each ms of charging adds 1 mm/ms of speed.
+type Linear struct{}
+
+func (Linear) Distance(hold, total float64) float64 {
+	return hold * (total - hold)
+}
+
+func (Linear) WinningRange(r Race) (first, last int, ok bool) {
+	return r.WinningRange()
+}
+
+func (Linear) Unimodal() bool { return true }
+
+// Quadratic models a boat whose speed grows with the square of the charge time: speed = Accel * hold^2.
+type Quadratic struct {
+	Accel float64
+}
+
+func (q Quadratic) Distance(hold, total float64) float64 {
+	return q.Accel * hold * hold * (total - hold)
+}
+
+func (Quadratic) Unimodal() bool { return true }
+
+// Capped models a boat with a top speed: each ms of charging adds 1 mm/ms of speed, up to Max.
+type Capped struct {
+	Max float64
+}
+
+func (c Capped) Distance(hold, total float64) float64 {
+	return math.Min(hold, c.Max) * (total - hold)
+}
+
+func (Capped) Unimodal() bool { return true }
+
+// Decay models a boat whose charge drains while it moves: speed starts at hold and decays exponentially at Rate per ms.
+type Decay struct {
+	Rate float64
+}
+
+func (d Decay) Distance(hold, total float64) float64 {
+	if d.Rate <= 0 {
+		return Linear{}.Distance(hold, total)
+	}
+	return hold * (1 - math.Exp(-d.Rate*(total-hold))) / d.Rate
+}
+
+func (Decay) Unimodal() bool { return true }
+
+// Interval is an inclusive range of hold times.
+type Interval struct {
+	First int
+	Last  int
+}
+
+func (i Interval) Len() int {
+	return i.Last - i.First + 1
+}
+
+// WinningIntervals returns the ranges of whole-ms hold times for which the model beats the race record distance.
+// Models with a closed form are solved directly. Unimodal models are solved by searching for the peak distance and
+// then binary searching each side for the record. All other models fall back to checking every hold time.
+func WinningIntervals(m Model, r Race) []Interval {
+	if cf, ok := m.(ClosedForm); ok {
+		first, last, ok := cf.WinningRange(r)
+		if !ok {
+			return []Interval{}
+		}
+		return []Interval{{First: first, Last: last}}
+	}
+
+	beats := func(hold int) bool {
+		return m.Distance(float64(hold), float64(r.Time)) > float64(r.Distance)
+	}
+
+	if u, ok := m.(Unimodal); ok && u.Unimodal() {
+		return searchUnimodal(m, r, beats)
+	}
+	return scan(r, beats)
+}
+
+// searchUnimodal finds the single winning interval of a unimodal model.
+func searchUnimodal(m Model, r Race, beats func(hold int) bool) []Interval {
+	dist := func(hold int) float64 {
+		return m.Distance(float64(hold), float64(r.Time))
+	}
+
+	// Ternary search for the hold time with the peak distance.
+	lo, hi := 0, r.Time
+	for hi-lo > 2 {
+		m1 := lo + (hi-lo)/3
+		m2 := hi - (hi-lo)/3
+		if dist(m1) < dist(m2) {
+			lo = m1 + 1
+		} else {
+			hi = m2
+		}
+	}
+	peak := lo
+	for h := lo + 1; h <= hi; h++ {
+		if dist(h) > dist(peak) {
+			peak = h
+		}
+	}
+	if !beats(peak) {
+		return []Interval{}
+	}
+
+	// Distance rises up to the peak, so find the first winning hold time before it...
+	lo, hi = 0, peak
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if beats(mid) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	first := lo
+
+	// ...and falls after it, so find the last winning hold time after it.
+	lo, hi = peak, r.Time
+	for lo < hi {
+		mid := lo + (hi-lo+1)/2
+		if beats(mid) {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return []Interval{{First: first, Last: lo}}
+}
+
+// scan checks every hold time, collecting consecutive winners into intervals.
+func scan(r Race, beats func(hold int) bool) []Interval {
+	intervals := []Interval{}
+	for hold := 0; hold <= r.Time; hold++ {
+		if !beats(hold) {
+			continue
+		}
+		n := len(intervals)
+		if n > 0 && intervals[n-1].Last == hold-1 {
+			intervals[n-1].Last = hold
+			continue
+		}
+		intervals = append(intervals, Interval{First: hold, Last: hold})
+	}
+	return intervals
+}
+
+// MarginOfErrorWith multiplies the number of winning hold times for each race under the given model.
+func (r Races) MarginOfErrorWith(m Model) int {
+	res := 1
+	for _, race := range r {
+		ways := 0
+		for _, i := range WinningIntervals(m, race) {
+			ways += i.Len()
+		}
+		res *= ways
+	}
+	return res
+}
@@ -0,0 +1,85 @@
+package race_test
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	race "github.com/harveysanders/advent-of-code-2023/day06-wait-for-it"
+	"github.com/stretchr/testify/require"
+)
+
+// scanIntervals is a brute-force reference for WinningIntervals.
+func scanIntervals(m race.Model, r race.Race) []race.Interval {
+	intervals := []race.Interval{}
+	for h := 0; h <= r.Time; h++ {
+		if m.Distance(float64(h), float64(r.Time)) <= float64(r.Distance) {
+			continue
+		}
+		if n := len(intervals); n > 0 && intervals[n-1].Last == h-1 {
+			intervals[n-1].Last = h
+			continue
+		}
+		intervals = append(intervals, race.Interval{First: h, Last: h})
+	}
+	return intervals
+}
+
+// wave is a model with several separate winning intervals and no closed form.
+type wave struct{}
+
+func (wave) Distance(hold, total float64) float64 {
+	return 10 * math.Sin(hold)
+}
+
+// linearScan is the puzzle model without the closed form or search hints.
+type linearScan struct{}
+
+func (linearScan) Distance(hold, total float64) float64 {
+	return hold * (total - hold)
+}
+
+func TestWinningIntervals(t *testing.T) {
+	models := []race.Model{
+		race.Linear{},
+		race.Quadratic{Accel: 0.5},
+		race.Capped{Max: 6},
+		race.Decay{Rate: 0.1},
+		race.Decay{},
+		wave{},
+		linearScan{},
+	}
+
+	for _, m := range models {
+		t.Run(fmt.Sprintf("%T%+v", m, m), func(t *testing.T) {
+			for time := 0; time <= 40; time++ {
+				for dist := 0; dist <= 200; dist += 3 {
+					r := race.Race{Time: time, Distance: dist}
+					want := scanIntervals(m, r)
+					got := race.WinningIntervals(m, r)
+					require.Equalf(t, want, got, "race: %+v", r)
+				}
+			}
+		})
+	}
+}
+
+func TestWinningIntervalsMultiple(t *testing.T) {
+	got := race.WinningIntervals(wave{}, race.Race{Time: 10, Distance: 5})
+	require.Equal(t, []race.Interval{{First: 1, Last: 2}, {First: 7, Last: 8}}, got)
+}
+
+func TestMarginOfErrorWith(t *testing.T) {
+	sample := `Time:      7  15   30
+Distance:  9  40  200
+`
+	races, err := race.Parse(strings.NewReader(sample), false)
+	require.NoError(t, err)
+
+	require.Equal(t, races.MarginOfError(), races.MarginOfErrorWith(race.Linear{}))
+	require.Equal(t, races.MarginOfError(), races.MarginOfErrorWith(linearScan{}))
+
+	// A top speed of 2 mm/ms can't beat the second race's 40 mm record in 15 ms.
+	require.Equal(t, 0, races.MarginOfErrorWith(race.Capped{Max: 2}))
+}
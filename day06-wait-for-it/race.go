@@ -2,8 +2,10 @@ package race
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"strconv"
 	"strings"
 )
@@ -13,87 +15,132 @@ type Race struct {
 	Distance int // Record distance a boat has traveled for the given race time, in millimeters.
 }
 
-// WinningTimes returns a list of charge button durations needed to beat the race record distance.
+// WinningTimes returns a list of charge button durations needed to beat the race record distance, shortest first.
 func (r Race) WinningTimes() []int {
-	winners := make(map[int]interface{})
-	for i := 1; i <= r.Time; i++ {
-		timeLeft := r.Time - i
-		dist := i * timeLeft
-		if dist > r.Distance {
-			winners[i] = nil
-		}
+	first, last, ok := r.WinningRange()
+	if !ok {
+		return []int{}
 	}
-	res := []int{}
-	for i := range winners {
+	res := make([]int, 0, last-first+1)
+	for i := first; i <= last; i++ {
 		res = append(res, i)
 	}
 	return res
 }
 
+// WinningRange returns the shortest and longest charge button durations that beat the race record distance.
+// Holding the button for h ms covers h*(Time-h) mm, so the winning durations are the integers strictly between
+// the roots of h^2 - Time*h + Distance = 0. ok is false if no duration wins.
+func (r Race) WinningRange() (first, last int, ok bool) {
+	disc := float64(r.Time)*float64(r.Time) - 4*float64(r.Distance)
+	if disc < 0 {
+		return 0, 0, false
+	}
+
+	// The float estimate of the lower root can be off by one in either direction,
+	// so step it onto the exact boundary using integer math.
+	mid := r.Time / 2
+	first = int(math.Floor((float64(r.Time) - math.Sqrt(disc)) / 2))
+	first = max(0, min(first, mid))
+	for first > 0 && r.beats(first-1) {
+		first--
+	}
+	for first <= mid && !r.beats(first) {
+		first++
+	}
+	if first > mid {
+		return 0, 0, false
+	}
+
+	// Distance is symmetric around Time/2.
+	return first, r.Time - first, true
+}
+
+// beats reports whether holding the button for hold ms beats the record distance.
+func (r Race) beats(hold int) bool {
+	return hold*(r.Time-hold) > r.Distance
+}
+
 type Races []Race
 
+// ErrOverflow is returned by Parse when a race's numbers are too large to solve with int. Use ParseBig instead.
+var ErrOverflow = errors.New("race overflows int")
+
+// Parse reads the race times and record distances. If mergeColumns is true, the columns are joined into a single race.
+// If a time or distance does not fit in an int, or a time is large enough for the distance calculation to overflow,
+// the returned error wraps ErrOverflow.
 func Parse(data io.Reader, mergeColumns bool) (Races, error) {
-	var races Races
+	rawTimes, rawDists, err := parseColumns(data, mergeColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	races := make(Races, len(rawTimes))
+	for i, v := range rawTimes {
+		ms, err := strconv.Atoi(v)
+		if errors.Is(err, strconv.ErrRange) {
+			return races, fmt.Errorf("%w: time: %s", ErrOverflow, v)
+		}
+		if err != nil {
+			return races, fmt.Errorf("strconv.Atoi(): %w, val: %s", err, v)
+		}
+		if ms > 0 && ms > math.MaxInt/ms {
+			return races, fmt.Errorf("%w: time: %s", ErrOverflow, v)
+		}
+		races[i].Time = ms
+	}
+	for i, v := range rawDists {
+		d, err := strconv.Atoi(v)
+		if errors.Is(err, strconv.ErrRange) {
+			return races, fmt.Errorf("%w: distance: %s", ErrOverflow, v)
+		}
+		if err != nil {
+			return races, fmt.Errorf("strconv.Atoi(): %w, val: %s", err, v)
+		}
+		races[i].Distance = d
+	}
+	return races, nil
+}
+
+// parseColumns reads the raw time and distance values. If mergeColumns is true, each row's values are joined into one.
+func parseColumns(data io.Reader, mergeColumns bool) (times, dists []string, err error) {
 	scr := bufio.NewScanner(data)
 	for scr.Scan() {
 		if scr.Err() != nil {
-			return races, scr.Err()
+			return times, dists, scr.Err()
 		}
 
 		line := scr.Text()
 		if strings.Contains(line, "Time") {
-			rawTimes := strings.Fields(strings.TrimSpace(strings.TrimPrefix(line, "Time:")))
-
+			times = strings.Fields(strings.TrimSpace(strings.TrimPrefix(line, "Time:")))
 			if mergeColumns {
-				races = []Race{}
-				ms, err := strconv.Atoi(strings.Join(rawTimes, ""))
-				if err != nil {
-					return races, fmt.Errorf("strconv.Atoi(): %w", err)
-				}
-				races = append(races, Race{Time: ms})
-				continue
-			}
-
-			races = make([]Race, len(rawTimes))
-			for i, v := range rawTimes {
-				ms, err := strconv.Atoi(v)
-				if err != nil {
-					return races, fmt.Errorf("strconv.Atoi(): %w, val: %s", err, v)
-				}
-				races[i] = Race{Time: ms}
+				times = []string{strings.Join(times, "")}
 			}
 			continue
 		}
 
 		if strings.Contains(line, "Distance") {
-			rawDists := strings.Fields(strings.TrimSpace(strings.TrimPrefix(line, "Distance:")))
-
+			dists = strings.Fields(strings.TrimSpace(strings.TrimPrefix(line, "Distance:")))
 			if mergeColumns {
-				d, err := strconv.Atoi(strings.Join(rawDists, ""))
-				if err != nil {
-					return races, fmt.Errorf("strconv.Atoi(): %w", err)
-				}
-				races[0].Distance = d
-				continue
-			}
-
-			for i, v := range rawDists {
-				d, err := strconv.Atoi(v)
-				if err != nil {
-					return races, fmt.Errorf("strconv.Atoi(): %w, val: %s", err, v)
-				}
-				races[i].Distance = d
+				dists = []string{strings.Join(dists, "")}
 			}
 			continue
 		}
 	}
-	return races, nil
+	if len(dists) > len(times) {
+		return times, dists, fmt.Errorf("%d distances for %d times", len(dists), len(times))
+	}
+	return times, dists, nil
 }
 
 func (r Races) MarginOfError() int {
 	res := 1
 	for _, race := range r {
-		res *= len(race.WinningTimes())
+		first, last, ok := race.WinningRange()
+		if !ok {
+			return 0
+		}
+		res *= last - first + 1
 	}
 	return res
 }
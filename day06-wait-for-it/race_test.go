@@ -149,3 +149,55 @@ func BenchmarkMarginOfError(b *testing.B) {
 		races.MarginOfError()
 	}
 }
+
+func TestWinningRange(t *testing.T) {
+	testCases := []struct {
+		race      race.Race
+		wantFirst int
+		wantLast  int
+		wantOK    bool
+	}{
+		{race: race.Race{Time: 7, Distance: 9}, wantFirst: 2, wantLast: 5, wantOK: true},
+		{race: race.Race{Time: 15, Distance: 40}, wantFirst: 4, wantLast: 11, wantOK: true},
+		// Roots are whole numbers (10 and 20), which only tie the record.
+		{race: race.Race{Time: 30, Distance: 200}, wantFirst: 11, wantLast: 19, wantOK: true},
+		{race: race.Race{Time: 71530, Distance: 940200}, wantFirst: 14, wantLast: 71516, wantOK: true},
+		// Best possible distance ties the record.
+		{race: race.Race{Time: 4, Distance: 4}, wantOK: false},
+		{race: race.Race{Time: 3, Distance: 10}, wantOK: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("time: %d ms, record: %d mm", tc.race.Time, tc.race.Distance), func(t *testing.T) {
+			first, last, ok := tc.race.WinningRange()
+			require.Equal(t, tc.wantOK, ok)
+			if !tc.wantOK {
+				return
+			}
+			require.Equal(t, tc.wantFirst, first)
+			require.Equal(t, tc.wantLast, last)
+		})
+	}
+}
+
+func TestWinningRangeMatchesScan(t *testing.T) {
+	for time := 0; time <= 60; time++ {
+		for dist := 0; dist <= time*time/4+1; dist++ {
+			r := race.Race{Time: time, Distance: dist}
+			want := []int{}
+			for h := 0; h <= time; h++ {
+				if h*(time-h) > dist {
+					want = append(want, h)
+				}
+			}
+
+			first, last, ok := r.WinningRange()
+			require.Equalf(t, len(want) > 0, ok, "race: %+v", r)
+			if ok {
+				require.Equalf(t, want[0], first, "race: %+v", r)
+				require.Equalf(t, want[len(want)-1], last, "race: %+v", r)
+			}
+			require.Equalf(t, want, r.WinningTimes(), "race: %+v", r)
+		}
+	}
+}
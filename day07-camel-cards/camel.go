@@ -5,7 +5,7 @@ import (
 	"cmp"
 	"fmt"
 	"io"
-	"math"
+	"math/bits"
 	"slices"
 	"strconv"
 	"strings"
@@ -29,22 +29,6 @@ const (
 	Label2 Label = "2"
 )
 
-var cardValues = map[Label]int{
-	LabelA: 14,
-	LabelK: 13,
-	LabelQ: 12,
-	LabelJ: 11,
-	LabelT: 10,
-	Label9: 9,
-	Label8: 8,
-	Label7: 7,
-	Label6: 6,
-	Label5: 5,
-	Label4: 4,
-	Label3: 3,
-	Label2: 2,
-}
-
 type HandType int
 
 const (
@@ -58,34 +42,109 @@ const (
 )
 
 func (t HandType) String() string {
-	names := []string{"", "high card", "one pair", "two pair", "three of a kind", "four of a kind", "five of a kind"}
+	names := []string{"high card", "one pair", "two pair", "three of a kind", "full house", "four of a kind", "five of a kind"}
+	if t < 0 || int(t) >= len(names) {
+		return fmt.Sprintf("hand type %d", t)
+	}
 	return names[t]
 }
 
+// Signature is the number of cards sharing each label in a hand, sorted from most to fewest.
+// For example, a full house has the signature [3 2].
+type Signature []int
+
+// handSignatures returns every signature for a hand of n cards, ordered from weakest to strongest.
+// Comparing signatures element by element gives the puzzle's ordering for five-card hands:
+//
+//	[1 1 1 1 1] < [2 1 1 1] < [2 2 1] < [3 1 1] < [3 2] < [4 1] < [5]
+//
+// so a HandType is the signature's index in this list.
+func handSignatures(n int) []Signature {
+	sigs := []Signature{}
+	var build func(remaining, maxPart int, prefix Signature)
+	build = func(remaining, maxPart int, prefix Signature) {
+		if remaining == 0 {
+			sigs = append(sigs, slices.Clone(prefix))
+			return
+		}
+		for part := min(remaining, maxPart); part > 0; part-- {
+			build(remaining-part, part, append(prefix, part))
+		}
+	}
+	build(n, n, Signature{})
+	slices.SortFunc(sigs, func(a, b Signature) int { return slices.Compare(a, b) })
+	return sigs
+}
+
+const defaultHandSize = 5
+
+// WildcardPolicy sets how a wildcard is valued when breaking ties between hands of the same type.
+type WildcardPolicy int
+
+const (
+	WildLowest  WildcardPolicy = iota // WildLowest values the wildcard below every other card.
+	WildNatural                       // WildNatural values the wildcard as its own label.
+	WildBest                          // WildBest values the wildcard as the card it substitutes for.
+)
+
 type Game struct {
-	wildcard Label
-	Hands    Hands
+	wildcards  map[Label]WildcardPolicy
+	deck       Deck
+	handSize   int
+	signatures []Signature // All hand signatures for the hand size, weakest first.
+	Hands      Hands
 }
 
 type GameOption func(*Game)
 
+// WithWildcard makes the card a wildcard that is the weakest card when breaking ties.
+// It can be used more than once to add several wildcards.
 func WithWildcard(card Label) GameOption {
+	return WithWildcardPolicy(card, WildLowest)
+}
+
+// WithWildcardPolicy makes the card a wildcard that is valued by the policy when breaking ties.
+func WithWildcardPolicy(card Label, policy WildcardPolicy) GameOption {
+	return func(g *Game) {
+		if g.wildcards == nil {
+			g.wildcards = make(map[Label]WildcardPolicy)
+		}
+		g.wildcards[card] = policy
+	}
+}
+
+// WithDeck sets the card labels and their order. Defaults to StandardDeck.
+func WithDeck(d Deck) GameOption {
 	return func(g *Game) {
-		g.wildcard = card
+		g.deck = d
+	}
+}
+
+// WithHandSize sets the number of cards in each hand. Defaults to 5.
+func WithHandSize(n int) GameOption {
+	return func(g *Game) {
+		g.handSize = n
 	}
 }
 
 func NewGame(opts ...GameOption) *Game {
-	g := &Game{}
+	g := &Game{
+		deck:     StandardDeck,
+		handSize: defaultHandSize,
+	}
 	for _, o := range opts {
 		if o == nil {
 			continue
 		}
 		o(g)
 	}
+	g.signatures = handSignatures(g.handSize)
 	return g
 }
 
+// defaultGame is used by hands that were not parsed as part of a game.
+var defaultGame = NewGame()
+
 func (g *Game) Parse(r io.Reader) error {
 	hands, err := ParseHands(r, withGame(g))
 	if err != nil {
@@ -95,50 +154,162 @@ func (g *Game) Parse(r io.Reader) error {
 	return nil
 }
 
+// NewHand creates a hand for the game from its labels, e.g. "K234J".
+func (g *Game) NewHand(labels string, bid int) (Hand, error) {
+	h := Hand{Bid: bid, game: g}
+	if err := h.ParseLabels(labels); err != nil {
+		return h, fmt.Errorf("hand.ParseLabels(): %w", err)
+	}
+	if err := h.checkSize(); err != nil {
+		return h, err
+	}
+	return h, nil
+}
+
+// Deck returns the game's card labels.
+func (g *Game) Deck() Deck {
+	return g.deck
+}
+
+// HandSize returns the number of cards in each hand.
+func (g *Game) HandSize() int {
+	return g.handSize
+}
+
+func (g *Game) hasWildcards() bool {
+	return len(g.wildcards) > 0
+}
+
+func (g *Game) isWild(l Label) bool {
+	_, ok := g.wildcards[l]
+	return ok
+}
+
+// handType returns the HandType of the signature.
+func (g *Game) handType(sig Signature) HandType {
+	sigs := g.signatures
+	if len(sig) > 0 && sumInts(sig) != g.handSize {
+		sigs = handSignatures(sumInts(sig))
+	}
+	i, _ := slices.BinarySearchFunc(sigs, sig, func(a, b Signature) int { return slices.Compare(a, b) })
+	return HandType(i)
+}
+
+func sumInts(vals []int) int {
+	sum := 0
+	for _, v := range vals {
+		sum += v
+	}
+	return sum
+}
+
 type Card struct {
 	Label Label
 	value int
 }
 
 type Hand struct {
-	Cards [5]Card
+	Cards []Card
 	Bid   int
 	game  *Game
 }
 
+func (h Hand) rules() *Game {
+	if h.game == nil {
+		return defaultGame
+	}
+	return h.game
+}
+
 // Type finds the number of sets of matching cards and returns the associated HandType.
+// If useWildcard is true, the type is the best one achievable by substituting the game's wildcards.
 func (h Hand) Type(useWildcard bool) HandType {
+	return h.rules().handType(h.Signature(useWildcard))
+}
+
+// Signature returns the hand's count signature, e.g. "23332" -> [3 2].
+// If useWildcard is true, the game's wildcards are counted as the label they substitute for.
+func (h Hand) Signature(useWildcard bool) Signature {
 	counts := h.cardCounts()
-	pairs := []Label{}
-	maxMatches := 0
-	wildCardCount := 0
-	if useWildcard && h.game != nil {
-		wildCardCount = counts[h.game.wildcard]
-	}
-	for label, count := range counts {
-		maxMatches = int(math.Max(float64(maxMatches+wildCardCount), float64(count)))
-		if count+wildCardCount == 2 {
-			pairs = append(pairs, label)
+	if target, ok := h.substitute(useWildcard); ok {
+		for label, count := range counts {
+			if h.rules().isWild(label) {
+				counts[target] += count
+				delete(counts, label)
+			}
+		}
+	}
+
+	sig := make(Signature, 0, len(counts))
+	for _, count := range counts {
+		sig = append(sig, count)
+	}
+	slices.SortFunc(sig, func(a, b int) int { return cmp.Compare(b, a) })
+	return sig
+}
+
+// substitute returns the label every wildcard in the hand becomes to make the strongest hand.
+// Moving all wildcards to the most common natural label gives the best type, and picking the
+// strongest of the most common labels gives the best tie-break for WildBest wildcards.
+// If the hand is all wildcards, they become the deck's strongest natural label.
+// ok is false if there are no wildcards to substitute.
+func (h Hand) substitute(useWildcard bool) (target Label, ok bool) {
+	g := h.rules()
+	if !useWildcard || !g.hasWildcards() {
+		return "", false
+	}
+
+	hasWild := false
+	bestCount, bestValue := 0, -1
+	for label, count := range h.cardCounts() {
+		if g.isWild(label) {
+			hasWild = true
+			continue
+		}
+		value, _ := g.deck.Value(label)
+		if count > bestCount || (count == bestCount && value > bestValue) {
+			target, bestCount, bestValue = label, count, value
 		}
 	}
-	// If 4 of a kind or higher, use that for type
-	if maxMatches >= 4 {
-		return HandType(maxMatches + 1)
+	if !hasWild {
+		return "", false
+	}
+	if target != "" {
+		return target, true
 	}
 
-	// # of pairs in the hand
-	nPairs := len(pairs)
-	if maxMatches == 3 {
-		if nPairs == 1 {
-			return FullHouse
+	for i := g.deck.Len() - 1; i >= 0; i-- {
+		if l := g.deck.labels[i]; !g.isWild(l) {
+			return l, true
 		}
-		return ThreeOfAKind
 	}
-	if nPairs > 0 {
-		return HandType(nPairs)
+	return "", false
+}
+
+// TieBreak returns the card values used to order hands of the same type, in hand order.
+// If useWildcard is true, wildcards are valued by their WildcardPolicy.
+func (h Hand) TieBreak(useWildcard bool) []int {
+	g := h.rules()
+	target, substituted := h.substitute(useWildcard)
+	targetValue, _ := g.deck.Value(target)
+
+	values := make([]int, len(h.Cards))
+	for i, c := range h.Cards {
+		values[i] = c.value
+		policy, isWild := g.wildcards[c.Label]
+		if !useWildcard || !isWild {
+			continue
+		}
+		switch policy {
+		case WildLowest:
+			values[i] = 1
+		case WildBest:
+			if substituted {
+				values[i] = targetValue
+			}
+		}
 	}
-	// No pairs, all distinct
-	return HighCard
+	return values
 }
 
 // CardCounts returns a map of card labels to their counts in the hand.
@@ -168,63 +339,98 @@ func (h Hand) String() string {
 	return s.String()
 }
 
-func (h Hand) hasWildcard() int {
-	if h.game == nil {
-		return 0
+func (h Hand) checkSize() error {
+	if size := h.rules().handSize; len(h.Cards) != size {
+		return fmt.Errorf("hand %q has %d cards, want %d", h, len(h.Cards), size)
 	}
-	if h.game.wildcard == "" {
-		return 0
-	}
-	if strings.Contains(h.String(), string(h.game.wildcard)) {
-		return 1
-	}
-	return 0
+	return nil
 }
 
 // ParseLabels takes a hand as a string, e.g. "K234J" and populates the hand struct.
-func (h *Hand) ParseLabels(raw string) {
+func (h *Hand) ParseLabels(raw string) error {
+	g := h.rules()
 	labels := strings.Split(raw, "")
+	h.Cards = make([]Card, len(labels))
 	for i, l := range labels {
 		label := Label(l)
-		value := cardValues[label]
-		if h.game != nil && h.game.wildcard == label {
-			value = 1
+		value, ok := g.deck.Value(label)
+		if !ok {
+			return fmt.Errorf("label %q not in deck %q", label, g.deck)
 		}
 		h.Cards[i] = Card{
-			Label: Label(label),
+			Label: label,
 			value: value,
 		}
 	}
+	return nil
 }
 
 type Hands []Hand
 
 // Rank sorts the hands in place, ordering by type strength, lowest rank first.
+// Each hand is encoded once into a sort key. If the game's hands are too large for a key, hands are compared directly.
 func (g Game) Rank() Hands {
-	copy := slices.Clone(g.Hands)
-	slices.SortStableFunc(copy, cmpHands(g.wildcard != ""))
-	return copy
-}
+	useWildcard := g.hasWildcards()
 
-func cmpHands(useWildcard bool) func(a Hand, b Hand) int {
-	return func(a, b Hand) int {
-		if n := cmp.Compare(a.Type(useWildcard), b.Type(useWildcard)); n != 0 {
-			return n
+	type keyedHand struct {
+		key  uint64
+		hand Hand
+	}
+	keyed := make([]keyedHand, len(g.Hands))
+	for i, h := range g.Hands {
+		key, ok := h.Key(useWildcard)
+		if !ok {
+			slices.SortStableFunc(g.Hands, cmpHands(useWildcard))
+			return g.Hands
 		}
+		keyed[i] = keyedHand{key: key, hand: h}
+	}
 
-		if useWildcard {
-			if n := cmpHands(false)(a, b); n != 0 {
-				return n
-			}
-		}
+	slices.SortStableFunc(keyed, func(a, b keyedHand) int {
+		return cmp.Compare(a.key, b.key)
+	})
+	for i, k := range keyed {
+		g.Hands[i] = k.hand
+	}
+	return g.Hands
+}
 
-		// If types are equal, order by card value
-		for i, aCard := range a.Cards {
-			if n := cmp.Compare(aCard.value, b.Cards[i].value); n != 0 {
-				return n
-			}
-		}
-		return 0
+// Key encodes the hand's type and tie-break values into a single integer that sorts the same way as Compare.
+// The type takes the high bits, followed by each card's value in hand order.
+// ok is false if the game's hand size and deck need more than 64 bits.
+func (h Hand) Key(useWildcard bool) (key uint64, ok bool) {
+	g := h.rules()
+	if len(h.Cards) != g.handSize {
+		return 0, false
+	}
+	cardBits := bits.Len(uint(g.deck.Len() + 1))
+	typeBits := bits.Len(uint(len(g.signatures) - 1))
+	if typeBits+cardBits*len(h.Cards) > 64 {
+		return 0, false
+	}
+
+	key = uint64(h.Type(useWildcard))
+	for _, v := range h.TieBreak(useWildcard) {
+		key = key<<cardBits | uint64(v)
+	}
+	return key, true
+}
+
+// Compare orders two hands of the same game by type, then by card value. It returns -1 if h is the weaker hand,
+// 1 if h is the stronger hand, and 0 if they are tied.
+func (h Hand) Compare(other Hand, useWildcard bool) int {
+	if n := cmp.Compare(h.Type(useWildcard), other.Type(useWildcard)); n != 0 {
+		return n
+	}
+
+	// If types are equal, order by card value. With wildcards, the type the hand has without them doesn't matter:
+	// KTJJT beats QQQJA because K > Q, even though it is only two pair without wildcards.
+	return slices.Compare(h.TieBreak(useWildcard), other.TieBreak(useWildcard))
+}
+
+func cmpHands(useWildcard bool) func(a Hand, b Hand) int {
+	return func(a, b Hand) int {
+		return a.Compare(b, useWildcard)
 	}
 }
 
@@ -257,29 +463,41 @@ func ParseHands(r io.Reader, opts ...handOption) (Hands, error) {
 			return hands, fmt.Errorf("scr.Err(): %w", scr.Err())
 		}
 
-		line := scr.Text()
-		p := strings.Fields(line)
-		if len(p) != 2 {
-			return hands, fmt.Errorf("invalid hand-bid line: %q", line)
-		}
-
-		rawBid := p[1]
-		bid, err := strconv.Atoi(rawBid)
+		hand, err := parseHand(scr.Text(), opts...)
 		if err != nil {
-			return hands, fmt.Errorf("strconv.Atoi: %w, val: %q", err, rawBid)
-		}
-
-		hand := Hand{
-			Cards: [5]Card{},
-			Bid:   bid,
+			return hands, err
 		}
-		for _, o := range opts {
-			o(&hand)
-		}
-
-		hand.ParseLabels(p[0])
 		hands = append(hands, hand)
 	}
 
 	return hands, nil
 }
+
+// parseHand parses a hand-bid line, e.g. "32T3K 765".
+func parseHand(line string, opts ...handOption) (Hand, error) {
+	p := strings.Fields(line)
+	if len(p) != 2 {
+		return Hand{}, fmt.Errorf("invalid hand-bid line: %q", line)
+	}
+
+	rawBid := p[1]
+	bid, err := strconv.Atoi(rawBid)
+	if err != nil {
+		return Hand{}, fmt.Errorf("strconv.Atoi: %w, val: %q", err, rawBid)
+	}
+
+	hand := Hand{
+		Bid: bid,
+	}
+	for _, o := range opts {
+		o(&hand)
+	}
+
+	if err := hand.ParseLabels(p[0]); err != nil {
+		return hand, fmt.Errorf("hand.ParseLabels(): %w", err)
+	}
+	if err := hand.checkSize(); err != nil {
+		return hand, err
+	}
+	return hand, nil
+}
@@ -31,7 +31,7 @@ QQQJA 483
 	}{
 		{
 			wantHand: camel.Hand{
-				Cards: [5]camel.Card{
+				Cards: []camel.Card{
 					{Label: camel.Label3}, {Label: camel.Label2}, {Label: camel.LabelT}, {Label: camel.Label3}, {Label: camel.LabelK},
 				},
 			},
@@ -39,7 +39,7 @@ QQQJA 483
 		},
 		{
 			wantHand: camel.Hand{
-				Cards: [5]camel.Card{
+				Cards: []camel.Card{
 					{Label: camel.LabelT}, {Label: camel.Label5}, {Label: camel.Label5}, {Label: camel.LabelJ}, {Label: camel.Label5},
 				},
 			},
@@ -71,12 +71,14 @@ func TestHandType(t *testing.T) {
 		{labels: "23456", wantType: camel.HighCard},
 		{labels: "23332", wantType: camel.FullHouse},
 		{labels: "AAAA9", wantType: camel.FourOfAKind},
+		{labels: "AAAAA", wantType: camel.FiveOfAKind},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.labels, func(t *testing.T) {
 			hand := camel.Hand{}
-			hand.ParseLabels(tc.labels)
+			err := hand.ParseLabels(tc.labels)
+			require.NoError(t, err)
 			require.Equal(t, tc.wantType.String(), hand.Type(false).String())
 		})
 	}
@@ -126,6 +128,28 @@ QKKKQ 13
 	}
 }
 
+func TestRankWildcard(t *testing.T) {
+	sample := strings.NewReader(`32T3K 765
+T55J5 684
+KK677 28
+KTJJT 220
+QQQJA 483
+`)
+	game := camel.NewGame(camel.WithWildcard(camel.LabelJ))
+	err := game.Parse(sample)
+	require.NoError(t, err)
+	game.Rank()
+
+	got := []string{}
+	for _, h := range game.Hands {
+		got = append(got, h.String())
+	}
+	// T55J5, QQQJA and KTJJT are all four of a kind with J wild. KTJJT is only two pair without the wildcard,
+	// but hands of the same type are ordered by their cards alone, so it still ranks highest (K > Q > T).
+	require.Equal(t, []string{"32T3K", "KK677", "T55J5", "QQQJA", "KTJJT"}, got)
+	require.Equal(t, 5905, game.TotalWinnings())
+}
+
 func TestWinnings(t *testing.T) {
 	sample := strings.NewReader(`32T3K 765
 T55J5 684
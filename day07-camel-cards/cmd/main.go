@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	camel "github.com/harveysanders/advent-of-code-2023/day07-camel-cards"
+	"github.com/harveysanders/advent-of-code-2023/internal/github"
+)
+
+func main() {
+	wildcards := flag.String("wild", "", "wildcard labels, e.g. \"J\"")
+	explain := flag.Bool("explain", false, "print how each hand was ranked")
+	flag.Parse()
+
+	useLocal := os.Getenv("CI") == ""
+	fullInput, err := github.GetInputFile(7, useLocal)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer fullInput.Close()
+
+	opts := []camel.GameOption{}
+	for _, l := range strings.Split(*wildcards, "") {
+		opts = append(opts, camel.WithWildcard(camel.Label(l)))
+	}
+
+	game := camel.NewGame(opts...)
+	if err := game.Parse(fullInput); err != nil {
+		log.Fatal(err)
+	}
+
+	if *explain {
+		ranked, explanations := game.RankExplained()
+		if err := printExplanations(os.Stdout, game.Deck(), ranked, explanations); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	fmt.Println(game.TotalWinnings())
+}
+
+func printExplanations(out io.Writer, deck camel.Deck, ranked camel.Hands, explanations []camel.Explanation) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RANK\tHAND\tBID\tCOUNTS\tWILDCARDS\tTYPE\tTIE-BREAK")
+	for i, e := range explanations {
+		fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%s\t%s\t%v\n",
+			i+1,
+			e.Hand,
+			ranked[i].Bid,
+			formatCounts(deck, e.Counts),
+			formatSubstitutions(deck, e.Substitutions),
+			e.Type,
+			e.TieBreak,
+		)
+	}
+	return w.Flush()
+}
+
+// formatCounts lists the label counts from the strongest label to the weakest, e.g. "K:1 T:2 J:2".
+func formatCounts(deck camel.Deck, counts map[camel.Label]int) string {
+	parts := []string{}
+	labels := deck.Labels()
+	slices.Reverse(labels)
+	for _, l := range labels {
+		if n, ok := counts[l]; ok {
+			parts = append(parts, fmt.Sprintf("%s:%d", l, n))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatSubstitutions lists which label each wildcard was counted as, e.g. "J->T".
+func formatSubstitutions(deck camel.Deck, subs map[camel.Label]camel.Label) string {
+	if len(subs) == 0 {
+		return "-"
+	}
+	parts := []string{}
+	for _, l := range deck.Labels() {
+		if target, ok := subs[l]; ok {
+			parts = append(parts, fmt.Sprintf("%s->%s", l, target))
+		}
+	}
+	return strings.Join(parts, " ")
+}
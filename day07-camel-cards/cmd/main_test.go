@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	camel "github.com/harveysanders/advent-of-code-2023/day07-camel-cards"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintExplanationsType(t *testing.T) {
+	game := camel.NewGame()
+	err := game.Parse(strings.NewReader(`23456 1
+32T3K 2
+KTJJT 3
+TTT98 4
+23332 5
+AA8AA 6
+AAAAA 7
+`))
+	require.NoError(t, err)
+
+	ranked, explanations := game.RankExplained()
+	var out bytes.Buffer
+	err = printExplanations(&out, game.Deck(), ranked, explanations)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	header := lines[0]
+	typeCol := strings.Index(header, "TYPE")
+	tieBreakCol := strings.Index(header, "TIE-BREAK")
+	got := []string{}
+	for _, line := range lines[1:] {
+		got = append(got, strings.TrimSpace(line[typeCol:tieBreakCol]))
+	}
+	require.Equal(t, []string{
+		"high card",
+		"one pair",
+		"two pair",
+		"three of a kind",
+		"full house",
+		"four of a kind",
+		"five of a kind",
+	}, got)
+}
@@ -0,0 +1,84 @@
+package camel
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Deck defines the card labels used in a game, ordered from weakest to strongest.
+type Deck struct {
+	labels []Label
+	values map[Label]int
+}
+
+// StandardDeck is the puzzle's deck: 2 is the weakest card and A is the strongest.
+var StandardDeck = MustParseDeck("23456789TJQKA")
+
+// NewDeck creates a deck from the labels, ordered from weakest to strongest.
+func NewDeck(labels ...Label) (Deck, error) {
+	d := Deck{
+		labels: make([]Label, 0, len(labels)),
+		values: make(map[Label]int, len(labels)),
+	}
+	if len(labels) == 0 {
+		return d, fmt.Errorf("deck has no labels")
+	}
+	for i, l := range labels {
+		if len([]rune(string(l))) != 1 {
+			return d, fmt.Errorf("label must be a single character: %q", l)
+		}
+		if _, ok := d.values[l]; ok {
+			return d, fmt.Errorf("duplicate label: %q", l)
+		}
+		// Values start at 2 so 1 is free for wildcards played as the weakest card.
+		d.values[l] = i + 2
+		d.labels = append(d.labels, l)
+	}
+	return d, nil
+}
+
+// ParseDeck creates a deck from a string of single-character labels, ordered from weakest to strongest.
+//
+// Ex:
+//
+//	ParseDeck("23456789TJQKA")
+func ParseDeck(labels string) (Deck, error) {
+	raw := strings.Split(labels, "")
+	deckLabels := make([]Label, len(raw))
+	for i, l := range raw {
+		deckLabels[i] = Label(l)
+	}
+	return NewDeck(deckLabels...)
+}
+
+// MustParseDeck is like ParseDeck but panics if the labels are invalid.
+func MustParseDeck(labels string) Deck {
+	d, err := ParseDeck(labels)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Labels returns the deck's labels, ordered from weakest to strongest.
+func (d Deck) Labels() []Label {
+	return append([]Label{}, d.labels...)
+}
+
+// Value returns the strength of the label, used to break ties between hands of the same type.
+func (d Deck) Value(l Label) (int, bool) {
+	v, ok := d.values[l]
+	return v, ok
+}
+
+func (d Deck) Len() int {
+	return len(d.labels)
+}
+
+func (d Deck) String() string {
+	var s strings.Builder
+	for _, l := range d.labels {
+		s.WriteString(string(l))
+	}
+	return s.String()
+}
@@ -0,0 +1,92 @@
+package camel_test
+
+import (
+	"strings"
+	"testing"
+
+	camel "github.com/harveysanders/advent-of-code-2023/day07-camel-cards"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeck(t *testing.T) {
+	deck, err := camel.ParseDeck("AKQ")
+	require.NoError(t, err)
+	require.Equal(t, []camel.Label{"A", "K", "Q"}, deck.Labels())
+
+	aVal, _ := deck.Value("A")
+	qVal, _ := deck.Value("Q")
+	require.Less(t, aVal, qVal)
+
+	_, ok := deck.Value("2")
+	require.False(t, ok)
+
+	_, err = camel.ParseDeck("AKA")
+	require.Error(t, err)
+
+	_, err = camel.ParseDeck("")
+	require.Error(t, err)
+
+	_, err = camel.NewDeck("10", "J")
+	require.Error(t, err)
+}
+
+func TestCustomDeck(t *testing.T) {
+	// Reversed ranks: 2 is the strongest card.
+	deck, err := camel.ParseDeck("AKQJT98765432")
+	require.NoError(t, err)
+
+	game := camel.NewGame(camel.WithDeck(deck))
+	err = game.Parse(strings.NewReader(`22345 1
+AAKQJ 2
+33345 3
+`))
+	require.NoError(t, err)
+
+	got := []string{}
+	for _, h := range game.Rank() {
+		got = append(got, h.String())
+	}
+	require.Equal(t, []string{"AAKQJ", "22345", "33345"}, got)
+
+	err = game.Parse(strings.NewReader("AAXQJ 2\n"))
+	require.Error(t, err)
+}
+
+func TestHandSize(t *testing.T) {
+	testCases := []struct {
+		labels   string
+		wantSig  camel.Signature
+		wantType camel.HandType
+	}{
+		{labels: "2345678", wantSig: camel.Signature{1, 1, 1, 1, 1, 1, 1}, wantType: 0},
+		{labels: "2234567", wantSig: camel.Signature{2, 1, 1, 1, 1, 1}, wantType: 1},
+		{labels: "2223334", wantSig: camel.Signature{3, 3, 1}, wantType: 7},
+		{labels: "2222333", wantSig: camel.Signature{4, 3}, wantType: 10},
+		{labels: "2222222", wantSig: camel.Signature{7}, wantType: 14},
+	}
+
+	input := strings.Builder{}
+	for i, tc := range testCases {
+		input.WriteString(tc.labels + " " + string(rune('1'+i)) + "\n")
+	}
+
+	game := camel.NewGame(camel.WithHandSize(7))
+	err := game.Parse(strings.NewReader(input.String()))
+	require.NoError(t, err)
+	require.Len(t, game.Hands, len(testCases))
+
+	for i, tc := range testCases {
+		t.Run(tc.labels, func(t *testing.T) {
+			hand := game.Hands[i]
+			require.Equal(t, tc.wantSig, hand.Signature(false))
+			require.Equal(t, tc.wantType, hand.Type(false))
+		})
+	}
+
+	// 15 partitions of 7, so the strongest type is 14.
+	ranked := game.Rank()
+	require.Equal(t, "2222222", ranked[len(ranked)-1].String())
+
+	err = game.Parse(strings.NewReader("23456 1\n"))
+	require.Error(t, err)
+}
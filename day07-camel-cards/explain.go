@@ -0,0 +1,42 @@
+package camel
+
+// Explanation describes how a hand was classified and ranked.
+type Explanation struct {
+	Hand          string
+	Counts        map[Label]int   // Number of cards with each label, before any wildcard substitution.
+	Substitutions map[Label]Label // Wildcard labels in the hand, mapped to the label they were counted as.
+	Signature     Signature       // Label counts after substitution, most common first.
+	Type          HandType
+	TieBreak      []int // Card values used to order hands of the same type, in hand order.
+}
+
+// Explain returns the details used to classify the hand. Wildcards are substituted if the hand's game has any.
+func (h Hand) Explain() Explanation {
+	useWildcard := h.rules().hasWildcards()
+	e := Explanation{
+		Hand:          h.String(),
+		Counts:        h.cardCounts(),
+		Substitutions: map[Label]Label{},
+		Signature:     h.Signature(useWildcard),
+		Type:          h.Type(useWildcard),
+		TieBreak:      h.TieBreak(useWildcard),
+	}
+	if target, ok := h.substitute(useWildcard); ok {
+		for label := range e.Counts {
+			if h.rules().isWild(label) {
+				e.Substitutions[label] = target
+			}
+		}
+	}
+	return e
+}
+
+// RankExplained ranks the hands like Rank and returns each ranked hand's explanation at the same index.
+func (g Game) RankExplained() (Hands, []Explanation) {
+	ranked := g.Rank()
+	explanations := make([]Explanation, len(ranked))
+	for i, h := range ranked {
+		explanations[i] = h.Explain()
+	}
+	return ranked, explanations
+}
@@ -0,0 +1,78 @@
+package camel_test
+
+import (
+	"strings"
+	"testing"
+
+	camel "github.com/harveysanders/advent-of-code-2023/day07-camel-cards"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplain(t *testing.T) {
+	game := camel.NewGame(camel.WithWildcard(camel.LabelJ))
+	testCases := []struct {
+		labels string
+		want   camel.Explanation
+	}{
+		{
+			labels: "KTJJT",
+			want: camel.Explanation{
+				Hand:          "KTJJT",
+				Counts:        map[camel.Label]int{"K": 1, "T": 2, "J": 2},
+				Substitutions: map[camel.Label]camel.Label{"J": "T"},
+				Signature:     camel.Signature{4, 1},
+				Type:          camel.FourOfAKind,
+				TieBreak:      []int{13, 10, 1, 1, 10},
+			},
+		},
+		{
+			labels: "32T3K",
+			want: camel.Explanation{
+				Hand:          "32T3K",
+				Counts:        map[camel.Label]int{"3": 2, "2": 1, "T": 1, "K": 1},
+				Substitutions: map[camel.Label]camel.Label{},
+				Signature:     camel.Signature{2, 1, 1, 1},
+				Type:          camel.OnePair,
+				TieBreak:      []int{3, 2, 10, 3, 13},
+			},
+		},
+		{
+			labels: "JJJJJ",
+			want: camel.Explanation{
+				Hand:          "JJJJJ",
+				Counts:        map[camel.Label]int{"J": 5},
+				Substitutions: map[camel.Label]camel.Label{"J": "A"},
+				Signature:     camel.Signature{5},
+				Type:          camel.FiveOfAKind,
+				TieBreak:      []int{1, 1, 1, 1, 1},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.labels, func(t *testing.T) {
+			hand, err := game.NewHand(tc.labels, 0)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, hand.Explain())
+		})
+	}
+}
+
+func TestRankExplained(t *testing.T) {
+	game := camel.NewGame()
+	err := game.Parse(strings.NewReader(`32T3K 765
+T55J5 684
+KK677 28
+KTJJT 220
+QQQJA 483
+`))
+	require.NoError(t, err)
+
+	ranked, explanations := game.RankExplained()
+	require.Equal(t, game.Rank(), ranked)
+	require.Len(t, explanations, len(ranked))
+	for i, h := range ranked {
+		require.Equal(t, h.String(), explanations[i].Hand)
+		require.Equal(t, h.Type(false), explanations[i].Type)
+	}
+}
@@ -0,0 +1,119 @@
+package camel_test
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	camel "github.com/harveysanders/advent-of-code-2023/day07-camel-cards"
+	"github.com/stretchr/testify/require"
+)
+
+// randomGame deals n random hands for a game with the given options.
+func randomGame(t testing.TB, rng *rand.Rand, n int, opts ...camel.GameOption) *camel.Game {
+	game := camel.NewGame(opts...)
+	labels := game.Deck().Labels()
+
+	var input strings.Builder
+	for i := 0; i < n; i++ {
+		for j := 0; j < game.HandSize(); j++ {
+			input.WriteString(string(labels[rng.Intn(len(labels))]))
+		}
+		fmt.Fprintf(&input, " %d\n", rng.Intn(1000)+1)
+	}
+	err := game.Parse(strings.NewReader(input.String()))
+	require.NoError(t, err)
+	return game
+}
+
+func TestKeyMatchesCompare(t *testing.T) {
+	testCases := []struct {
+		name        string
+		opts        []camel.GameOption
+		useWildcard bool
+	}{
+		{name: "standard"},
+		{name: "joker", opts: []camel.GameOption{camel.WithWildcard(camel.LabelJ)}, useWildcard: true},
+		{
+			name:        "mixed wildcard policies",
+			useWildcard: true,
+			opts: []camel.GameOption{
+				camel.WithWildcardPolicy(camel.LabelJ, camel.WildBest),
+				camel.WithWildcardPolicy(camel.Label2, camel.WildNatural),
+				camel.WithWildcard(camel.LabelQ),
+			},
+		},
+		{name: "small deck, long hands", opts: []camel.GameOption{camel.WithDeck(camel.MustParseDeck("234")), camel.WithHandSize(9)}},
+	}
+
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(int64(i)))
+			game := randomGame(t, rng, 500, tc.opts...)
+			useWildcard := tc.useWildcard
+
+			for j := 0; j < 5000; j++ {
+				a := game.Hands[rng.Intn(len(game.Hands))]
+				b := game.Hands[rng.Intn(len(game.Hands))]
+				aKey, ok := a.Key(useWildcard)
+				require.True(t, ok)
+				bKey, ok := b.Key(useWildcard)
+				require.True(t, ok)
+
+				require.Equalf(t, a.Compare(b, useWildcard), cmp.Compare(aKey, bKey), "hands: %s, %s", a, b)
+			}
+
+			want := slices.Clone(game.Hands)
+			slices.SortStableFunc(want, func(a, b camel.Hand) int { return a.Compare(b, useWildcard) })
+			require.Equal(t, want, game.Rank())
+		})
+	}
+}
+
+func TestKeyTooLarge(t *testing.T) {
+	game := camel.NewGame(camel.WithHandSize(20))
+	hand, err := game.NewHand("23456789TJQKA23456789", 1)
+	require.Error(t, err)
+
+	hand, err = game.NewHand("23456789TJQKA2345678", 1)
+	require.NoError(t, err)
+	_, ok := hand.Key(false)
+	require.False(t, ok)
+
+	other, err := game.NewHand("22222222222222222223", 2)
+	require.NoError(t, err)
+	game.Hands = camel.Hands{other, hand}
+	require.Equal(t, camel.Hands{hand, other}, game.Rank())
+}
+
+// benchmarkRank times rank on the same unsorted hands each iteration, since Rank sorts the game's hands in place.
+func benchmarkRank(b *testing.B, rank func(g *camel.Game) camel.Hands) {
+	for _, n := range []int{1_000, 10_000} {
+		game := randomGame(b, rand.New(rand.NewSource(7)), n, camel.WithWildcard(camel.LabelJ))
+		dealt := game.Hands
+		b.Run(fmt.Sprintf("%d hands", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				game.Hands = slices.Clone(dealt)
+				b.StartTimer()
+				rank(game)
+			}
+		})
+	}
+}
+
+func BenchmarkRankKey(b *testing.B) {
+	benchmarkRank(b, func(g *camel.Game) camel.Hands {
+		return g.Rank()
+	})
+}
+
+func BenchmarkRankCompare(b *testing.B) {
+	benchmarkRank(b, func(g *camel.Game) camel.Hands {
+		slices.SortStableFunc(g.Hands, func(a, b camel.Hand) int { return a.Compare(b, true) })
+		return g.Hands
+	})
+}
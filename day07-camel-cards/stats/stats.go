@@ -0,0 +1,137 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"strings"
+
+	camel "github.com/harveysanders/advent-of-code-2023/day07-camel-cards"
+)
+
+// TypeProbabilities returns the exact probability of being dealt each hand type, where each card in a hand is drawn
+// independently and uniformly from the game's deck. If useWildcard is true, hands are typed with the game's wildcards.
+//
+// Hand type only depends on which labels are in a hand, not their order, so each multiset of labels is typed once and
+// weighted by the number of ways to order it.
+func TypeProbabilities(game *camel.Game, useWildcard bool) (map[camel.HandType]*big.Rat, error) {
+	labels := game.Deck().Labels()
+	size := game.HandSize()
+
+	ways := map[camel.HandType]*big.Int{}
+	counts := make([]int, len(labels))
+	var err error
+	var enumerate func(labelIdx, remaining int)
+	enumerate = func(labelIdx, remaining int) {
+		if err != nil {
+			return
+		}
+		if labelIdx == len(labels)-1 {
+			counts[labelIdx] = remaining
+			var hand camel.Hand
+			hand, err = game.NewHand(multisetLabels(labels, counts), 0)
+			if err != nil {
+				return
+			}
+			t := hand.Type(useWildcard)
+			if _, ok := ways[t]; !ok {
+				ways[t] = new(big.Int)
+			}
+			ways[t].Add(ways[t], orderings(size, counts))
+			return
+		}
+		for n := remaining; n >= 0; n-- {
+			counts[labelIdx] = n
+			enumerate(labelIdx+1, remaining-n)
+		}
+		counts[labelIdx] = 0
+	}
+	enumerate(0, size)
+	if err != nil {
+		return nil, fmt.Errorf("game.NewHand(): %w", err)
+	}
+
+	total := new(big.Int).Exp(big.NewInt(int64(len(labels))), big.NewInt(int64(size)), nil)
+	probs := make(map[camel.HandType]*big.Rat, len(ways))
+	for t, n := range ways {
+		probs[t] = new(big.Rat).SetFrac(n, total)
+	}
+	return probs, nil
+}
+
+// multisetLabels writes out a hand with counts[i] cards of labels[i].
+func multisetLabels(labels []camel.Label, counts []int) string {
+	var s strings.Builder
+	for i, n := range counts {
+		s.WriteString(strings.Repeat(string(labels[i]), n))
+	}
+	return s.String()
+}
+
+// orderings returns the number of distinct hands of the given size with the label counts: size! / (c1! * c2! * ...).
+func orderings(size int, counts []int) *big.Int {
+	n := new(big.Int).MulRange(1, int64(size))
+	for _, c := range counts {
+		n.Div(n, new(big.Int).MulRange(1, int64(c)))
+	}
+	return n
+}
+
+// SimOptions configures ExpectedWinnings.
+type SimOptions struct {
+	Hands  int                    // Number of hands dealt in each game.
+	Trials int                    // Number of games to simulate.
+	Seed   int64                  // Seed for the random number generator, so results can be reproduced.
+	Bid    func(r *rand.Rand) int // Draws a bid for a hand. Defaults to a uniform bid from 1 to 1000.
+}
+
+// Estimate summarizes the total winnings across simulated games.
+type Estimate struct {
+	Mean   float64
+	StdDev float64
+	Trials int
+}
+
+// ExpectedWinnings estimates a game's total winnings by dealing random hands with random bids.
+// The game options set the deck, hand size and wildcards used for each simulated game.
+func ExpectedWinnings(sim SimOptions, opts ...camel.GameOption) (Estimate, error) {
+	if sim.Hands <= 0 || sim.Trials <= 0 {
+		return Estimate{}, fmt.Errorf("hands and trials must be positive, got %d hands, %d trials", sim.Hands, sim.Trials)
+	}
+	if sim.Bid == nil {
+		sim.Bid = func(r *rand.Rand) int { return r.Intn(1000) + 1 }
+	}
+
+	rng := rand.New(rand.NewSource(sim.Seed))
+	var sum, sumSquares float64
+	for i := 0; i < sim.Trials; i++ {
+		game := camel.NewGame(opts...)
+		labels := game.Deck().Labels()
+		game.Hands = make(camel.Hands, sim.Hands)
+		for j := range game.Hands {
+			var s strings.Builder
+			for k := 0; k < game.HandSize(); k++ {
+				s.WriteString(string(labels[rng.Intn(len(labels))]))
+			}
+			hand, err := game.NewHand(s.String(), sim.Bid(rng))
+			if err != nil {
+				return Estimate{}, fmt.Errorf("game.NewHand(): %w", err)
+			}
+			game.Hands[j] = hand
+		}
+
+		total := float64(game.TotalWinnings())
+		sum += total
+		sumSquares += total * total
+	}
+
+	n := float64(sim.Trials)
+	mean := sum / n
+	variance := math.Max(0, sumSquares/n-mean*mean)
+	return Estimate{
+		Mean:   mean,
+		StdDev: math.Sqrt(variance),
+		Trials: sim.Trials,
+	}, nil
+}
@@ -0,0 +1,111 @@
+package stats_test
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	camel "github.com/harveysanders/advent-of-code-2023/day07-camel-cards"
+	"github.com/harveysanders/advent-of-code-2023/day07-camel-cards/stats"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeProbabilitiesStandard(t *testing.T) {
+	probs, err := stats.TypeProbabilities(camel.NewGame(), false)
+	require.NoError(t, err)
+
+	// 13^5 = 371293 equally likely hands.
+	want := map[camel.HandType]string{
+		camel.HighCard:     "154440/371293",
+		camel.OnePair:      "171600/371293",
+		camel.TwoPair:      "25740/371293",
+		camel.ThreeOfAKind: "17160/371293",
+		camel.FullHouse:    "1560/371293",
+		camel.FourOfAKind:  "780/371293",
+		camel.FiveOfAKind:  "1/28561",
+	}
+	require.Len(t, probs, len(want))
+	for handType, p := range want {
+		wantP, _ := new(big.Rat).SetString(p)
+		require.Equalf(t, wantP.String(), probs[handType].String(), "type: %s", handType)
+	}
+}
+
+func TestTypeProbabilitiesMatchEnumeration(t *testing.T) {
+	testCases := []struct {
+		name        string
+		opts        []camel.GameOption
+		useWildcard bool
+	}{
+		{
+			name: "small deck",
+			opts: []camel.GameOption{camel.WithDeck(camel.MustParseDeck("23J4")), camel.WithHandSize(4)},
+		},
+		{
+			name:        "small deck with wildcards",
+			opts:        []camel.GameOption{camel.WithDeck(camel.MustParseDeck("23J4Q")), camel.WithHandSize(4), camel.WithWildcard("J"), camel.WithWildcard("Q")},
+			useWildcard: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			game := camel.NewGame(tc.opts...)
+			probs, err := stats.TypeProbabilities(game, tc.useWildcard)
+			require.NoError(t, err)
+
+			// Deal every possible hand.
+			labels := game.Deck().Labels()
+			hands := []string{""}
+			for i := 0; i < game.HandSize(); i++ {
+				next := []string{}
+				for _, h := range hands {
+					for _, l := range labels {
+						next = append(next, h+string(l))
+					}
+				}
+				hands = next
+			}
+			counts := map[camel.HandType]int64{}
+			for _, labels := range hands {
+				hand, err := game.NewHand(labels, 0)
+				require.NoError(t, err)
+				counts[hand.Type(tc.useWildcard)]++
+			}
+
+			sum := new(big.Rat)
+			require.Len(t, probs, len(counts))
+			for handType, n := range counts {
+				want := big.NewRat(n, int64(len(hands)))
+				require.Equalf(t, want.String(), probs[handType].String(), "type: %s", handType)
+				sum.Add(sum, probs[handType])
+			}
+			require.Equal(t, "1", sum.RatString())
+		})
+	}
+}
+
+func TestExpectedWinnings(t *testing.T) {
+	sim := stats.SimOptions{Hands: 100, Trials: 200, Seed: 42}
+	got, err := stats.ExpectedWinnings(sim, camel.WithWildcard(camel.LabelJ))
+	require.NoError(t, err)
+	require.Equal(t, 200, got.Trials)
+
+	// Same seed, same result.
+	again, err := stats.ExpectedWinnings(sim, camel.WithWildcard(camel.LabelJ))
+	require.NoError(t, err)
+	require.Equal(t, got, again)
+
+	// With every bid fixed at 1, the winnings are 1 + 2 + ... + 100 no matter how hands are ranked.
+	sim.Bid = func(r *rand.Rand) int { return 1 }
+	fixed, err := stats.ExpectedWinnings(sim)
+	require.NoError(t, err)
+	require.Equal(t, float64(5050), fixed.Mean)
+	require.Zero(t, fixed.StdDev)
+
+	// Uniform bids from 1 to 1000 average 500.5, so the expected total is about 500.5 * 5050.
+	require.InEpsilon(t, 500.5*5050, got.Mean, 0.05)
+
+	_, err = stats.ExpectedWinnings(stats.SimOptions{})
+	require.Error(t, err)
+}
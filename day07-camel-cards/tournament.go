@@ -0,0 +1,281 @@
+package camel
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// HandID identifies a hand entered in a Tournament.
+type HandID int
+
+// Tournament keeps a game's hands ranked as hands are added and removed. The ranking is stored in an
+// order-statistics tree, so adding or removing a hand, finding a hand's rank, and the total winnings
+// are all logarithmic in the number of hands.
+//
+// Hands of equal strength are ranked in the order they were added, matching Game.Rank.
+type Tournament struct {
+	game        *Game
+	useWildcard bool
+	root        *rankNode
+	nodes       map[HandID]*rankNode
+	nextID      HandID
+	rng         *rand.Rand
+}
+
+// rankNode is a treap node, ordered by hand strength then by ID, and heap-ordered by priority.
+type rankNode struct {
+	id       HandID
+	hand     Hand
+	key      uint64
+	keyed    bool
+	priority int64
+
+	left  *rankNode
+	right *rankNode
+
+	size     int // Number of hands in the subtree.
+	bidSum   int // Sum of the subtree's bids.
+	winnings int // Sum of bid * rank within the subtree.
+}
+
+func NewTournament(opts ...GameOption) *Tournament {
+	g := NewGame(opts...)
+	return &Tournament{
+		game:        g,
+		useWildcard: g.hasWildcards(),
+		nodes:       make(map[HandID]*rankNode),
+		rng:         rand.New(rand.NewSource(1)),
+	}
+}
+
+// Game returns the rules hands are ranked by.
+func (t *Tournament) Game() *Game {
+	return t.game
+}
+
+func (t *Tournament) Len() int {
+	return size(t.root)
+}
+
+// Add enters a hand, e.g. Add("32T3K", 765), and returns its ID.
+func (t *Tournament) Add(labels string, bid int) (HandID, error) {
+	h, err := t.game.NewHand(labels, bid)
+	if err != nil {
+		return 0, err
+	}
+	return t.insert(h), nil
+}
+
+// Ingest reads hand-bid lines from r as they arrive, entering each hand. It returns the IDs of the hands added,
+// including those added before any error.
+func (t *Tournament) Ingest(r io.Reader) ([]HandID, error) {
+	ids := []HandID{}
+	scr := bufio.NewScanner(r)
+	for scr.Scan() {
+		if scr.Text() == "" {
+			continue
+		}
+		h, err := parseHand(scr.Text(), withGame(t.game))
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, t.insert(h))
+	}
+	if scr.Err() != nil {
+		return ids, fmt.Errorf("scr.Err(): %w", scr.Err())
+	}
+	return ids, nil
+}
+
+// Remove takes the hand out of the tournament, returning false if it was not entered.
+func (t *Tournament) Remove(id HandID) bool {
+	n, ok := t.nodes[id]
+	if !ok {
+		return false
+	}
+	t.root = t.delete(t.root, n)
+	delete(t.nodes, id)
+	return true
+}
+
+// Hand returns the entered hand with the ID.
+func (t *Tournament) Hand(id HandID) (Hand, bool) {
+	n, ok := t.nodes[id]
+	if !ok {
+		return Hand{}, false
+	}
+	return n.hand, true
+}
+
+// Rank returns the hand's rank, where 1 is the weakest hand.
+func (t *Tournament) Rank(id HandID) (int, bool) {
+	target, ok := t.nodes[id]
+	if !ok {
+		return 0, false
+	}
+	rank := 0
+	n := t.root
+	for n != nil {
+		c := t.compare(target, n)
+		if c == 0 {
+			return rank + size(n.left) + 1, true
+		}
+		if c < 0 {
+			n = n.left
+			continue
+		}
+		rank += size(n.left) + 1
+		n = n.right
+	}
+	return 0, false
+}
+
+// At returns the hand with the rank, where 1 is the weakest hand.
+func (t *Tournament) At(rank int) (Hand, HandID, bool) {
+	n := t.root
+	for n != nil {
+		leftSize := size(n.left)
+		switch {
+		case rank <= leftSize:
+			n = n.left
+		case rank == leftSize+1:
+			return n.hand, n.id, true
+		default:
+			rank -= leftSize + 1
+			n = n.right
+		}
+	}
+	return Hand{}, 0, false
+}
+
+// Hands returns the entered hands, weakest first.
+func (t *Tournament) Hands() Hands {
+	hands := make(Hands, 0, t.Len())
+	var walk func(n *rankNode)
+	walk = func(n *rankNode) {
+		if n == nil {
+			return
+		}
+		walk(n.left)
+		hands = append(hands, n.hand)
+		walk(n.right)
+	}
+	walk(t.root)
+	return hands
+}
+
+// TotalWinnings returns the sum of each hand's bid multiplied by its rank.
+func (t *Tournament) TotalWinnings() int {
+	if t.root == nil {
+		return 0
+	}
+	return t.root.winnings
+}
+
+func (t *Tournament) insert(h Hand) HandID {
+	id := t.nextID
+	t.nextID++
+
+	n := &rankNode{
+		id:       id,
+		hand:     h,
+		priority: t.rng.Int63(),
+	}
+	n.key, n.keyed = h.Key(t.useWildcard)
+	n.update()
+
+	left, right := t.split(t.root, n)
+	t.root = merge(merge(left, n), right)
+	t.nodes[id] = n
+	return id
+}
+
+// compare orders nodes by hand strength, then by the order they were added.
+func (t *Tournament) compare(a, b *rankNode) int {
+	var c int
+	if a.keyed && b.keyed {
+		c = cmp.Compare(a.key, b.key)
+	} else {
+		c = a.hand.Compare(b.hand, t.useWildcard)
+	}
+	if c != 0 {
+		return c
+	}
+	return cmp.Compare(a.id, b.id)
+}
+
+// split divides the subtree into nodes ordered before pivot and nodes ordered after it.
+func (t *Tournament) split(n, pivot *rankNode) (left, right *rankNode) {
+	if n == nil {
+		return nil, nil
+	}
+	if t.compare(n, pivot) < 0 {
+		n.right, right = t.split(n.right, pivot)
+		n.update()
+		return n, right
+	}
+	left, n.left = t.split(n.left, pivot)
+	n.update()
+	return left, n
+}
+
+// merge joins two subtrees where every node in left is ordered before every node in right.
+func merge(left, right *rankNode) *rankNode {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+	if left.priority > right.priority {
+		left.right = merge(left.right, right)
+		left.update()
+		return left
+	}
+	right.left = merge(left, right.left)
+	right.update()
+	return right
+}
+
+func (t *Tournament) delete(n, target *rankNode) *rankNode {
+	if n == nil {
+		return nil
+	}
+	switch c := t.compare(target, n); {
+	case c == 0:
+		return merge(n.left, n.right)
+	case c < 0:
+		n.left = t.delete(n.left, target)
+	default:
+		n.right = t.delete(n.right, target)
+	}
+	n.update()
+	return n
+}
+
+// update recalculates the node's subtree totals from its children.
+func (n *rankNode) update() {
+	leftSize := size(n.left)
+	n.size = leftSize + 1 + size(n.right)
+	n.bidSum = n.hand.Bid
+	n.winnings = (leftSize + 1) * n.hand.Bid
+	if n.left != nil {
+		n.bidSum += n.left.bidSum
+		n.winnings += n.left.winnings
+	}
+	if n.right != nil {
+		n.bidSum += n.right.bidSum
+		// Every hand on the right ranks behind the left subtree and this node.
+		n.winnings += n.right.winnings + (leftSize+1)*n.right.bidSum
+	}
+}
+
+func size(n *rankNode) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
@@ -0,0 +1,139 @@
+package camel_test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	camel "github.com/harveysanders/advent-of-code-2023/day07-camel-cards"
+	"github.com/stretchr/testify/require"
+)
+
+func handLabels(hands camel.Hands) []string {
+	labels := make([]string, len(hands))
+	for i, h := range hands {
+		labels[i] = h.String()
+	}
+	return labels
+}
+
+func TestTournamentIngest(t *testing.T) {
+	sample := `32T3K 765
+T55J5 684
+KK677 28
+KTJJT 220
+QQQJA 483
+`
+	testCases := []struct {
+		name      string
+		opts      []camel.GameOption
+		wantTotal int
+	}{
+		{name: "part 1", wantTotal: 6440},
+		{name: "part 2", opts: []camel.GameOption{camel.WithWildcard(camel.LabelJ)}, wantTotal: 5905},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tour := camel.NewTournament(tc.opts...)
+			ids, err := tour.Ingest(strings.NewReader(sample))
+			require.NoError(t, err)
+			require.Len(t, ids, 5)
+			require.Equal(t, tc.wantTotal, tour.TotalWinnings())
+
+			game := camel.NewGame(tc.opts...)
+			err = game.Parse(strings.NewReader(sample))
+			require.NoError(t, err)
+			require.Equal(t, handLabels(game.Rank()), handLabels(tour.Hands()))
+		})
+	}
+}
+
+func TestTournamentRank(t *testing.T) {
+	tour := camel.NewTournament()
+	weak, err := tour.Add("32T3K", 765)
+	require.NoError(t, err)
+	strong, err := tour.Add("QQQJA", 483)
+	require.NoError(t, err)
+	tied, err := tour.Add("32T3K", 1)
+	require.NoError(t, err)
+
+	// Equal hands rank in the order they were added.
+	for id, wantRank := range map[camel.HandID]int{weak: 1, tied: 2, strong: 3} {
+		rank, ok := tour.Rank(id)
+		require.True(t, ok)
+		require.Equal(t, wantRank, rank)
+
+		_, atID, ok := tour.At(wantRank)
+		require.True(t, ok)
+		require.Equal(t, id, atID)
+	}
+	require.Equal(t, 765+2*1+3*483, tour.TotalWinnings())
+
+	require.True(t, tour.Remove(weak))
+	require.False(t, tour.Remove(weak))
+	_, ok := tour.Rank(weak)
+	require.False(t, ok)
+	_, _, ok = tour.At(3)
+	require.False(t, ok)
+
+	rank, _ := tour.Rank(strong)
+	require.Equal(t, 2, rank)
+	require.Equal(t, 1+2*483, tour.TotalWinnings())
+
+	_, err = tour.Add("32T3", 1)
+	require.Error(t, err)
+	_, err = tour.Ingest(strings.NewReader("32T3K\n"))
+	require.Error(t, err)
+}
+
+func TestTournamentMatchesGame(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	opts := []camel.GameOption{camel.WithWildcard(camel.LabelJ)}
+	pool := randomGame(t, rng, 400, opts...).Hands
+
+	tour := camel.NewTournament(opts...)
+	entered := map[camel.HandID]camel.Hand{}
+	order := []camel.HandID{}
+	for i, h := range pool {
+		id, err := tour.Add(h.String(), h.Bid)
+		require.NoError(t, err)
+		entered[id] = h
+		order = append(order, id)
+
+		// Remove a random hand now and then.
+		if i%3 == 2 {
+			j := rng.Intn(len(order))
+			require.True(t, tour.Remove(order[j]))
+			delete(entered, order[j])
+			order = append(order[:j], order[j+1:]...)
+		}
+
+		if i%25 != 0 {
+			continue
+		}
+		game := camel.NewGame(opts...)
+		for _, id := range order {
+			game.Hands = append(game.Hands, entered[id])
+		}
+		ranked := game.Rank()
+		require.Equal(t, game.TotalWinnings(), tour.TotalWinnings())
+		require.Equal(t, len(ranked), tour.Len())
+		for rank, h := range ranked {
+			got, _, ok := tour.At(rank + 1)
+			require.True(t, ok)
+			require.Equal(t, h.String(), got.String())
+			require.Equal(t, h.Bid, got.Bid)
+		}
+	}
+
+	for _, id := range order {
+		rank, ok := tour.Rank(id)
+		require.True(t, ok)
+		got, ok := tour.Hand(id)
+		require.True(t, ok)
+		atHand, atID, _ := tour.At(rank)
+		require.Equal(t, id, atID)
+		require.Equal(t, got, atHand)
+	}
+}
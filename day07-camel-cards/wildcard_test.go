@@ -0,0 +1,192 @@
+package camel_test
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	camel "github.com/harveysanders/advent-of-code-2023/day07-camel-cards"
+	"github.com/stretchr/testify/require"
+)
+
+type typeAndTieBreak struct {
+	handType camel.HandType
+	tieBreak []int
+}
+
+func (a typeAndTieBreak) compare(b typeAndTieBreak) int {
+	if a.handType != b.handType {
+		return int(a.handType - b.handType)
+	}
+	return slices.Compare(a.tieBreak, b.tieBreak)
+}
+
+// bestSubstitution tries every substitution of the hand's wildcards with the deck's natural labels and returns
+// the strongest resulting type and tie-break values.
+func bestSubstitution(t *testing.T, deck camel.Deck, policies map[camel.Label]camel.WildcardPolicy, labels string) typeAndTieBreak {
+	natural := []camel.Label{}
+	for _, l := range deck.Labels() {
+		if _, ok := policies[l]; !ok {
+			natural = append(natural, l)
+		}
+	}
+	plain := camel.NewGame(camel.WithDeck(deck), camel.WithHandSize(len(labels)))
+
+	var best *typeAndTieBreak
+	substituted := []rune(labels)
+	var try func(i int)
+	try = func(i int) {
+		if i == len(labels) {
+			hand, err := plain.NewHand(string(substituted), 0)
+			require.NoError(t, err)
+
+			got := typeAndTieBreak{handType: hand.Type(false), tieBreak: make([]int, len(labels))}
+			for j, r := range labels {
+				got.tieBreak[j], _ = deck.Value(camel.Label(substituted[j]))
+				switch policy, ok := policies[camel.Label(r)]; {
+				case ok && policy == camel.WildLowest:
+					got.tieBreak[j] = 1
+				case ok && policy == camel.WildNatural:
+					got.tieBreak[j], _ = deck.Value(camel.Label(r))
+				}
+			}
+			if best == nil || got.compare(*best) > 0 {
+				best = &got
+			}
+			return
+		}
+		if _, ok := policies[camel.Label(labels[i])]; !ok {
+			try(i + 1)
+			return
+		}
+		for _, l := range natural {
+			substituted[i] = []rune(string(l))[0]
+			try(i + 1)
+		}
+		substituted[i] = rune(labels[i])
+	}
+	try(0)
+	return *best
+}
+
+// allHands returns every hand of the given size that can be dealt from the deck.
+func allHands(deck camel.Deck, size int) []string {
+	hands := []string{""}
+	for i := 0; i < size; i++ {
+		next := make([]string, 0, len(hands)*deck.Len())
+		for _, h := range hands {
+			for _, l := range deck.Labels() {
+				next = append(next, h+string(l))
+			}
+		}
+		hands = next
+	}
+	return hands
+}
+
+func TestWildcardsMatchBruteForce(t *testing.T) {
+	testCases := []struct {
+		deck     string
+		handSize int
+		policies map[camel.Label]camel.WildcardPolicy
+	}{
+		{
+			deck:     "234JQK",
+			handSize: 4,
+			policies: map[camel.Label]camel.WildcardPolicy{"J": camel.WildLowest, "Q": camel.WildBest, "K": camel.WildNatural},
+		},
+		{
+			deck:     "2345J",
+			handSize: 5,
+			policies: map[camel.Label]camel.WildcardPolicy{"J": camel.WildBest},
+		},
+		{
+			deck:     "23JQA",
+			handSize: 5,
+			policies: map[camel.Label]camel.WildcardPolicy{"J": camel.WildLowest, "Q": camel.WildLowest},
+		},
+		{
+			deck:     "23J45",
+			handSize: 5,
+			policies: map[camel.Label]camel.WildcardPolicy{"J": camel.WildNatural},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("deck: %s, size: %d", tc.deck, tc.handSize), func(t *testing.T) {
+			deck, err := camel.ParseDeck(tc.deck)
+			require.NoError(t, err)
+
+			opts := []camel.GameOption{camel.WithDeck(deck), camel.WithHandSize(tc.handSize)}
+			for label, policy := range tc.policies {
+				opts = append(opts, camel.WithWildcardPolicy(label, policy))
+			}
+			game := camel.NewGame(opts...)
+
+			for _, labels := range allHands(deck, tc.handSize) {
+				hand, err := game.NewHand(labels, 0)
+				require.NoError(t, err)
+
+				want := bestSubstitution(t, deck, tc.policies, labels)
+				require.Equalf(t, want.handType, hand.Type(true), "hand: %s", labels)
+				require.Equalf(t, want.tieBreak, hand.TieBreak(true), "hand: %s", labels)
+			}
+		})
+	}
+}
+
+func TestWildcardPolicies(t *testing.T) {
+	input := `JKKK2 1
+QQQQ2 2
+`
+	testCases := []struct {
+		name      string
+		policy    camel.WildcardPolicy
+		wantOrder []string
+	}{
+		// J is worth less than Q, so JKKK2 is weaker.
+		{name: "lowest", policy: camel.WildLowest, wantOrder: []string{"JKKK2", "QQQQ2"}},
+		// J keeps its natural value, which is still less than Q.
+		{name: "natural", policy: camel.WildNatural, wantOrder: []string{"JKKK2", "QQQQ2"}},
+		// J becomes a K, which beats Q.
+		{name: "best", policy: camel.WildBest, wantOrder: []string{"QQQQ2", "JKKK2"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			game := camel.NewGame(camel.WithWildcardPolicy(camel.LabelJ, tc.policy))
+			err := game.Parse(strings.NewReader(input))
+			require.NoError(t, err)
+
+			got := []string{}
+			for _, h := range game.Rank() {
+				require.Equal(t, camel.FourOfAKind, h.Type(true))
+				got = append(got, h.String())
+			}
+			require.Equal(t, tc.wantOrder, got)
+		})
+	}
+}
+
+func TestMultipleWildcards(t *testing.T) {
+	game := camel.NewGame(camel.WithWildcard(camel.LabelJ), camel.WithWildcard(camel.Label2))
+	testCases := []struct {
+		labels   string
+		wantType camel.HandType
+	}{
+		{labels: "J2345", wantType: camel.ThreeOfAKind},
+		{labels: "J2J2J", wantType: camel.FiveOfAKind},
+		{labels: "J2KKQ", wantType: camel.FourOfAKind},
+		{labels: "J3344", wantType: camel.FullHouse},
+		{labels: "34567", wantType: camel.HighCard},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.labels, func(t *testing.T) {
+			hand, err := game.NewHand(tc.labels, 0)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantType, hand.Type(true))
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package wasteland
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrDanglingRef is returned when a node's left or right node is not defined in the map.
+var ErrDanglingRef = errors.New("reference to undefined node")
+
+// Validate checks that every node's left and right nodes are defined in the map.
+// The returned error joins an error wrapping ErrDanglingRef for each undefined reference.
+func (m NodeMap) Validate() error {
+	errs := []error{}
+	for _, name := range m.names() {
+		n := m.Nodes[name]
+		if _, ok := m.Nodes[n.Left]; !ok {
+			errs = append(errs, fmt.Errorf("%w: %q left node %q", ErrDanglingRef, name, n.Left))
+		}
+		if _, ok := m.Nodes[n.Right]; !ok {
+			errs = append(errs, fmt.Errorf("%w: %q right node %q", ErrDanglingRef, name, n.Right))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Analysis describes the structure of a node map, ignoring the order of the left/right instructions.
+// Because any instruction sequence can only follow a subset of the edges, nodes reported as unreachable or dead
+// are never visited or useful during a walk, but the reverse is not guaranteed.
+type Analysis struct {
+	Components  [][]string // Strongly connected components, each sorted by name, ordered by their first name.
+	Unreachable []string   // Nodes that can't be reached from any start node.
+	Dead        []string   // Nodes that can never reach an end node.
+	SelfLoops   []string   // Nodes, like DDD = (DDD, DDD), whose left and right nodes are both themselves.
+}
+
+// Analyze reports the structure of the node map for walks from nodes matching start to nodes matching end.
+func (m NodeMap) Analyze(start, end Matcher) Analysis {
+	reachable := m.reach(m.Matching(start), m.successors)
+	canFinish := m.reach(m.Matching(end), m.predecessors())
+
+	a := Analysis{
+		Components:  m.Components(),
+		Unreachable: []string{},
+		Dead:        []string{},
+		SelfLoops:   []string{},
+	}
+	for _, name := range m.names() {
+		n := m.Nodes[name]
+		if !reachable[name] {
+			a.Unreachable = append(a.Unreachable, name)
+		}
+		if !canFinish[name] {
+			a.Dead = append(a.Dead, name)
+		}
+		if n.Left == name && n.Right == name {
+			a.SelfLoops = append(a.SelfLoops, name)
+		}
+	}
+	return a
+}
+
+// Components returns the strongly connected components of the node map, found with Tarjan's algorithm.
+// Each component is sorted by name, and the components are ordered by their first name.
+func (m NodeMap) Components() [][]string {
+	type frame struct {
+		name string
+		next int // Index of the next successor to visit.
+	}
+
+	index := map[string]int{}
+	low := map[string]int{}
+	onStack := map[string]bool{}
+	stack := []string{}
+	components := [][]string{}
+
+	// Walk with an explicit call stack, since the chains of nodes can be long.
+	for _, root := range m.names() {
+		if _, ok := index[root]; ok {
+			continue
+		}
+		calls := []frame{{name: root}}
+		index[root], low[root] = len(index), len(index)
+		stack = append(stack, root)
+		onStack[root] = true
+
+		for len(calls) > 0 {
+			f := &calls[len(calls)-1]
+			succ := m.successors(f.name)
+			if f.next < len(succ) {
+				w := succ[f.next]
+				f.next++
+				if _, ok := index[w]; !ok {
+					index[w], low[w] = len(index), len(index)
+					stack = append(stack, w)
+					onStack[w] = true
+					calls = append(calls, frame{name: w})
+				} else if onStack[w] {
+					low[f.name] = min(low[f.name], index[w])
+				}
+				continue
+			}
+
+			v := f.name
+			calls = calls[:len(calls)-1]
+			if len(calls) > 0 {
+				parent := calls[len(calls)-1].name
+				low[parent] = min(low[parent], low[v])
+			}
+			if low[v] != index[v] {
+				continue
+			}
+
+			component := []string{}
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				component = append(component, w)
+				if w == v {
+					break
+				}
+			}
+			slices.Sort(component)
+			components = append(components, component)
+		}
+	}
+
+	slices.SortFunc(components, func(a, b []string) int {
+		return cmp.Compare(a[0], b[0])
+	})
+	return components
+}
+
+// reach returns the names of the nodes reachable from the start nodes, including the start nodes themselves.
+func (m NodeMap) reach(starts []Node, next func(name string) []string) map[string]bool {
+	seen := map[string]bool{}
+	queue := []string{}
+	for _, n := range starts {
+		seen[n.Name] = true
+		queue = append(queue, n.Name)
+	}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		for _, w := range next(name) {
+			if !seen[w] {
+				seen[w] = true
+				queue = append(queue, w)
+			}
+		}
+	}
+	return seen
+}
+
+// successors returns the defined left and right nodes of the node with the name.
+func (m NodeMap) successors(name string) []string {
+	n := m.Nodes[name]
+	res := make([]string, 0, 2)
+	for _, w := range []string{n.Left, n.Right} {
+		if _, ok := m.Nodes[w]; ok {
+			res = append(res, w)
+		}
+	}
+	return res
+}
+
+// predecessors returns a function listing the nodes with an edge to the node with the name.
+func (m NodeMap) predecessors() func(name string) []string {
+	prev := map[string][]string{}
+	for _, name := range m.names() {
+		for _, w := range m.successors(name) {
+			prev[w] = append(prev[w], name)
+		}
+	}
+	return func(name string) []string { return prev[name] }
+}
+
+// names returns the node names, sorted.
+func (m NodeMap) names() []string {
+	names := make([]string, 0, len(m.Nodes))
+	for name := range m.Nodes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
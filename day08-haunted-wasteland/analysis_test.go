@@ -0,0 +1,65 @@
+package wasteland_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	wl "github.com/harveysanders/advent-of-code-2023/day08-haunted-wasteland"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyze(t *testing.T) {
+	input := `RL
+
+AAA = (BBB, CCC)
+BBB = (DDD, EEE)
+CCC = (ZZZ, GGG)
+DDD = (DDD, DDD)
+EEE = (EEE, EEE)
+GGG = (GGG, GGG)
+ZZZ = (ZZZ, ZZZ)
+XXX = (YYY, YYY)
+YYY = (XXX, ZZZ)
+`
+	nodeMap, err := wl.ParseNodeMap(strings.NewReader(input))
+	require.NoError(t, err)
+
+	got := nodeMap.Analyze(wl.Exact("AAA"), wl.Exact("ZZZ"))
+	require.Equal(t, wl.Analysis{
+		Components: [][]string{
+			{"AAA"}, {"BBB"}, {"CCC"}, {"DDD"}, {"EEE"}, {"GGG"}, {"XXX", "YYY"}, {"ZZZ"},
+		},
+		Unreachable: []string{"XXX", "YYY"},
+		Dead:        []string{"BBB", "DDD", "EEE", "GGG"},
+		SelfLoops:   []string{"DDD", "EEE", "GGG", "ZZZ"},
+	}, got)
+}
+
+func TestComponentsLongCycle(t *testing.T) {
+	// A single cycle through every node, deeper than a recursive search would like.
+	var input strings.Builder
+	input.WriteString("L\n\n")
+	const n = 100_000
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&input, "N%d = (N%d, N%d)\n", i, (i+1)%n, i)
+	}
+
+	nodeMap, err := wl.ParseNodeMap(strings.NewReader(input.String()))
+	require.NoError(t, err)
+
+	components := nodeMap.Components()
+	require.Len(t, components, 1)
+	require.Len(t, components[0], n)
+}
+
+func TestParseNodeMapValidation(t *testing.T) {
+	_, err := wl.ParseNodeMap(strings.NewReader(`LR
+
+AAA = (BBB, QQQ)
+BBB = (AAA, PPP)
+`))
+	require.ErrorIs(t, err, wl.ErrDanglingRef)
+	require.ErrorContains(t, err, `"AAA" right node "QQQ"`)
+	require.ErrorContains(t, err, `"BBB" right node "PPP"`)
+}
@@ -1,6 +1,9 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
+	"fmt"
 	"log"
 	"os"
 
@@ -9,6 +12,21 @@ import (
 )
 
 func main() {
+	startPattern := flag.String("start", "suffix:A", "pattern matching the ghosts' start nodes")
+	endPattern := flag.String("end", "suffix:Z", "pattern matching the ghosts' end nodes")
+	dotFile := flag.String("dot", "", "write the node map as Graphviz DOT to this file, highlighting each ghost's cycle")
+	jsonFile := flag.String("json", "", "write the node map as JSON to this file")
+	flag.Parse()
+
+	start, err := wl.ParsePattern(*startPattern)
+	if err != nil {
+		log.Fatal(err)
+	}
+	end, err := wl.ParsePattern(*endPattern)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	useLocal := os.Getenv("CI") == ""
 	fullInput, err := github.GetInputFile(8, useLocal)
 	if err != nil {
@@ -21,10 +39,38 @@ func main() {
 		log.Fatal(err)
 	}
 
-	gotSteps, err := nodeMap.TraverseParallel("A", "Z")
+	if *dotFile != "" {
+		if err := writeDOT(*dotFile, nodeMap, wl.DOTOptions{Start: start, End: end, Cycles: true}); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *jsonFile != "" {
+		data, err := json.MarshalIndent(nodeMap, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(*jsonFile, data, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	gotSteps, err := nodeMap.SolveGhosts(start, end)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	log.Printf("FINAL: %d\n", gotSteps)
 }
+
+func writeDOT(name string, nodeMap wl.NodeMap, opts wl.DOTOptions) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return fmt.Errorf("os.Create(): %w", err)
+	}
+	defer f.Close()
+
+	if err := nodeMap.WriteDOT(f, opts); err != nil {
+		return fmt.Errorf("nodeMap.WriteDOT(): %w", err)
+	}
+	return f.Close()
+}
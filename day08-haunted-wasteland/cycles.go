@@ -0,0 +1,213 @@
+package wasteland
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrNoSolution is returned when the ghosts are never on end nodes at the same time.
+var ErrNoSolution = errors.New("ghosts never finish together")
+
+// GhostCycle describes the path of a single ghost. Because the ghost's next move only depends on its node and
+// its position in the left/right instructions, the path always ends up repeating.
+type GhostCycle struct {
+	Start      string // Name of the node the ghost starts on.
+	CycleStart int    // Step at which the ghost first enters the repeating part of its path.
+	CycleLen   int    // Number of steps in the repeating part of the path.
+	Hits       []int  // Steps before CycleStart+CycleLen at which the ghost is on an end node, in order.
+}
+
+// progression is the set of steps offset, offset+period, offset+2*period, ...
+// A period of 0 means only the offset itself.
+type progression struct {
+	offset int
+	period int
+}
+
+// steps returns every step at which the ghost is on an end node, as progressions.
+// Hits before the cycle only happen once; hits inside the cycle repeat every CycleLen steps.
+func (c GhostCycle) steps() []progression {
+	res := make([]progression, 0, len(c.Hits))
+	for _, hit := range c.Hits {
+		if hit < c.CycleStart {
+			res = append(res, progression{offset: hit})
+			continue
+		}
+		res = append(res, progression{offset: hit, period: c.CycleLen})
+	}
+	return res
+}
+
+type ghostState struct {
+	node  string
+	lrIdx int
+}
+
+// GhostCycles walks a ghost from each node matching start until it repeats a (node, instruction) state,
+// recording when it is on a node matching end.
+func (m NodeMap) GhostCycles(start, end Matcher) ([]GhostCycle, error) {
+	starts := m.Matching(start)
+	cycles := make([]GhostCycle, 0, len(starts))
+	for _, n := range starts {
+		c, err := m.ghostCycle(n.Name, end)
+		if err != nil {
+			return cycles, fmt.Errorf("ghost %q: %w", n.Name, err)
+		}
+		cycles = append(cycles, c)
+	}
+	return cycles, nil
+}
+
+func (m NodeMap) ghostCycle(start string, end Matcher) (GhostCycle, error) {
+	c := GhostCycle{Start: start, Hits: []int{}}
+	if len(m.LR) == 0 {
+		return c, fmt.Errorf("no left/right instructions")
+	}
+
+	seen := map[ghostState]int{}
+	node, ok := m.Nodes[start]
+	if !ok {
+		return c, fmt.Errorf("node not found: %q", start)
+	}
+	for step := 0; ; step++ {
+		state := ghostState{node: node.Name, lrIdx: step % len(m.LR)}
+		if first, ok := seen[state]; ok {
+			c.CycleStart = first
+			c.CycleLen = step - first
+			return c, nil
+		}
+		seen[state] = step
+
+		if end(node) {
+			c.Hits = append(c.Hits, step)
+		}
+
+		next, err := m.step(node, state.lrIdx)
+		if err != nil {
+			return c, err
+		}
+		node = next
+	}
+}
+
+// SolveGhosts returns the first step at which every ghost, starting from each node matching start, is on a node
+// matching end. It finds each ghost's cycle, then combines them: with the LCM of the cycle lengths when each ghost
+// hits an end exactly once per cycle, at a step equal to its cycle length, and with the generalized Chinese
+// Remainder Theorem otherwise. The returned error wraps ErrNoSolution if the ghosts never finish together.
+func (m NodeMap) SolveGhosts(start, end Matcher) (int, error) {
+	cycles, err := m.GhostCycles(start, end)
+	if err != nil {
+		return 0, err
+	}
+	if len(cycles) == 0 {
+		return 0, fmt.Errorf("no start nodes matched")
+	}
+	for _, c := range cycles {
+		if len(c.Hits) == 0 {
+			return 0, fmt.Errorf("%w: ghost %q never reaches an end node", ErrNoSolution, c.Start)
+		}
+	}
+
+	if steps, ok := lcmCycles(cycles); ok {
+		return steps, nil
+	}
+
+	candidates := cycles[0].steps()
+	for _, c := range cycles[1:] {
+		next := []progression{}
+		for _, a := range candidates {
+			for _, b := range c.steps() {
+				p, ok, err := intersect(a, b)
+				if err != nil {
+					return 0, err
+				}
+				if ok {
+					next = append(next, p)
+				}
+			}
+		}
+		if len(next) == 0 {
+			return 0, fmt.Errorf("%w: ghost %q can't finish with the ghosts before it", ErrNoSolution, c.Start)
+		}
+		candidates = next
+	}
+
+	best := candidates[0].offset
+	for _, p := range candidates[1:] {
+		best = min(best, p.offset)
+	}
+	return best, nil
+}
+
+// lcmCycles handles the common case where each ghost is on an end node only at multiples of its cycle length.
+func lcmCycles(cycles []GhostCycle) (int, bool) {
+	res := 1
+	for _, c := range cycles {
+		if len(c.Hits) != 1 || c.Hits[0] != c.CycleLen || c.CycleStart > c.CycleLen {
+			return 0, false
+		}
+		res = lcm(res, c.CycleLen)
+	}
+	return res, true
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func lcm(a, b int) int {
+	return a / gcd(a, b) * b
+}
+
+// intersect returns the steps in both progressions. Each progression only includes steps at or after its offset,
+// so the result starts at the first common step.
+func intersect(a, b progression) (progression, bool, error) {
+	if a.period == 0 && b.period == 0 {
+		return a, a.offset == b.offset, nil
+	}
+	if a.period == 0 {
+		a, b = b, a
+	}
+	if b.period == 0 {
+		ok := b.offset >= a.offset && (b.offset-a.offset)%a.period == 0
+		return b, ok, nil
+	}
+
+	// Solve t = a.offset (mod a.period) and t = b.offset (mod b.period).
+	// Work in big.Int since the combined period is the product of the periods over their GCD.
+	p, q := big.NewInt(int64(a.period)), big.NewInt(int64(b.period))
+	g, x := new(big.Int), new(big.Int)
+	g.GCD(x, nil, p, q)
+
+	diff := big.NewInt(int64(b.offset - a.offset))
+	if new(big.Int).Mod(diff, g).Sign() != 0 {
+		return progression{}, false, nil
+	}
+
+	// t = a.offset + a.period * k, where k = x * diff/g (mod q/g).
+	qg := new(big.Int).Div(q, g)
+	k := new(big.Int).Div(diff, g)
+	k.Mul(k, x).Mod(k, qg)
+
+	period := new(big.Int).Mul(p, qg)
+	t := new(big.Int).Mul(p, k)
+	t.Add(t, big.NewInt(int64(a.offset)))
+
+	// Move to the first common step at or after both offsets.
+	lowest := big.NewInt(int64(max(a.offset, b.offset)))
+	if t.Cmp(lowest) < 0 {
+		behind := new(big.Int).Sub(lowest, t)
+		periods := new(big.Int).Add(behind, new(big.Int).Sub(period, big.NewInt(1)))
+		periods.Div(periods, period)
+		t.Add(t, periods.Mul(periods, period))
+	}
+
+	if !t.IsInt64() || !period.IsInt64() {
+		return progression{}, false, fmt.Errorf("steps overflow int: %s (period %s)", t, period)
+	}
+	return progression{offset: int(t.Int64()), period: int(period.Int64())}, true, nil
+}
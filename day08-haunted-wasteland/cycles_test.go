@@ -0,0 +1,143 @@
+package wasteland_test
+
+import (
+	"strings"
+	"testing"
+
+	wl "github.com/harveysanders/advent-of-code-2023/day08-haunted-wasteland"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGhostCycles(t *testing.T) {
+	input := strings.NewReader(`LR
+
+11A = (11B, XXX)
+11B = (XXX, 11Z)
+11Z = (11B, XXX)
+22A = (22B, XXX)
+22B = (22C, 22C)
+22C = (22Z, 22Z)
+22Z = (22B, 22B)
+XXX = (XXX, XXX)
+`)
+	nodeMap, err := wl.ParseNodeMap(input)
+	require.NoError(t, err)
+
+	cycles, err := nodeMap.GhostCycles(wl.Suffix("A"), wl.Suffix("Z"))
+	require.NoError(t, err)
+	require.Equal(t, []wl.GhostCycle{
+		{Start: "11A", CycleStart: 1, CycleLen: 2, Hits: []int{2}},
+		{Start: "22A", CycleStart: 1, CycleLen: 6, Hits: []int{3, 6}},
+	}, cycles)
+}
+
+func TestSolveGhosts(t *testing.T) {
+	testCases := []struct {
+		name      string
+		input     string
+		wantSteps int
+		wantErr   error
+	}{
+		{
+			name: "sample part 2",
+			input: `LR
+
+11A = (11B, XXX)
+11B = (XXX, 11Z)
+11Z = (11B, XXX)
+22A = (22B, XXX)
+22B = (22C, 22C)
+22C = (22Z, 22Z)
+22Z = (22B, 22B)
+XXX = (XXX, XXX)
+`,
+			wantSteps: 6,
+		},
+		{
+			name: "cycles line up with LCM",
+			input: `L
+
+1A = (1B, 1B)
+1B = (1Z, 1Z)
+1Z = (1B, 1B)
+2A = (2B, 2B)
+2B = (2C, 2C)
+2C = (2Z, 2Z)
+2Z = (2B, 2B)
+`,
+			wantSteps: 6,
+		},
+		{
+			name: "offsets need CRT",
+			// Ghost 1 finishes at steps 2, 5, 8, ... and ghost 2 at steps 1, 6, 11, ...
+			input: `L
+
+1A = (1B, 1B)
+1B = (1Z, 1Z)
+1Z = (1C, 1C)
+1C = (1B, 1B)
+2A = (2Z, 2Z)
+2Z = (2B, 2B)
+2B = (2C, 2C)
+2C = (2D, 2D)
+2D = (2A, 2A)
+`,
+			wantSteps: 11,
+		},
+		{
+			name: "finish before the cycle",
+			// Ghost 1 only finishes at step 1, ghost 2 at steps 1, 5, 9, ...
+			input: `L
+
+1A = (1Z, 1Z)
+1Z = (1B, 1B)
+1B = (1B, 1B)
+2A = (2Z, 2Z)
+2Z = (2B, 2B)
+2B = (2C, 2C)
+2C = (2A, 2A)
+`,
+			wantSteps: 1,
+		},
+		{
+			name: "even and odd steps never line up",
+			// Ghost 1 finishes at even steps, ghost 2 at steps 1, 5, 9, ...
+			input: `L
+
+1A = (1B, 1B)
+1B = (1Z, 1Z)
+1Z = (1B, 1B)
+2A = (2Z, 2Z)
+2Z = (2B, 2B)
+2B = (2C, 2C)
+2C = (2A, 2A)
+`,
+			wantErr: wl.ErrNoSolution,
+		},
+		{
+			name: "ghost never finishes",
+			input: `L
+
+1A = (1Z, 1Z)
+1Z = (1Z, 1Z)
+2A = (2A, 2A)
+`,
+			wantErr: wl.ErrNoSolution,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			nodeMap, err := wl.ParseNodeMap(strings.NewReader(tc.input))
+			require.NoError(t, err)
+
+			gotSteps, err := nodeMap.SolveGhosts(wl.Suffix("A"), wl.Suffix("Z"))
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantSteps, gotSteps)
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package wasteland
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// DOTOptions controls how WriteDOT draws the node map.
+type DOTOptions struct {
+	Start  Matcher // Nodes filled green. Also where highlighted paths and cycles start. Optional.
+	End    Matcher // Nodes filled red. Also where highlighted paths end. Optional.
+	Path   bool    // Highlight the path from the single Start node to the first End node, as TraverseSingle walks it.
+	Cycles bool    // Highlight the repeating part of each ghost's path from the Start nodes.
+}
+
+// highlightColors are used in turn for each highlighted path or cycle.
+var highlightColors = []string{"blue", "darkorange", "purple", "brown", "magenta", "darkcyan"}
+
+// edge is a move from a node in a direction.
+type edge struct {
+	from string
+	dir  Direction
+}
+
+// WriteDOT writes the node map as a Graphviz DOT digraph. Each node has an edge labeled L to its left node and
+// one labeled R to its right node.
+//
+// Ex:
+//
+//	dot -Tsvg wasteland.dot -o wasteland.svg
+func (m NodeMap) WriteDOT(w io.Writer, opts DOTOptions) error {
+	highlights, err := m.highlights(opts)
+	if err != nil {
+		return err
+	}
+
+	names := m.names()
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph wasteland {")
+	for _, name := range names {
+		n := m.Nodes[name]
+		switch {
+		case opts.Start != nil && opts.Start(n):
+			fmt.Fprintf(bw, "\t%q [style=filled, fillcolor=palegreen];\n", name)
+		case opts.End != nil && opts.End(n):
+			fmt.Fprintf(bw, "\t%q [style=filled, fillcolor=salmon];\n", name)
+		}
+	}
+	for _, name := range names {
+		n := m.Nodes[name]
+		for _, e := range []struct {
+			dir Direction
+			to  string
+		}{{DirLeft, n.Left}, {DirRight, n.Right}} {
+			attrs := fmt.Sprintf("label=%q", e.dir)
+			if color, ok := highlights[edge{from: name, dir: e.dir}]; ok {
+				attrs += fmt.Sprintf(", color=%s, fontcolor=%s, penwidth=2", color, color)
+			}
+			fmt.Fprintf(bw, "\t%q -> %q [%s];\n", name, e.to, attrs)
+		}
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// highlights returns the color of each edge on the paths and cycles selected by opts.
+// An edge shared by several paths keeps the color of the first.
+func (m NodeMap) highlights(opts DOTOptions) (map[edge]string, error) {
+	res := map[edge]string{}
+	if !opts.Path && !opts.Cycles {
+		return res, nil
+	}
+	if opts.Start == nil {
+		return res, fmt.Errorf("highlighting needs a start matcher")
+	}
+
+	add := func(edges []edge, color string) {
+		for _, e := range edges {
+			if _, ok := res[e]; !ok {
+				res[e] = color
+			}
+		}
+	}
+
+	next := 0
+	if opts.Path {
+		if opts.End == nil {
+			return res, fmt.Errorf("highlighting a path needs an end matcher")
+		}
+		steps, err := m.Walk(opts.Start, opts.End, 0)
+		if err != nil {
+			return res, fmt.Errorf("m.Walk(): %w", err)
+		}
+		edges, err := m.trace(m.Matching(opts.Start)[0], steps)
+		if err != nil {
+			return res, err
+		}
+		add(edges, highlightColors[next%len(highlightColors)])
+		next++
+	}
+
+	if opts.Cycles {
+		end := opts.End
+		if end == nil {
+			end = func(Node) bool { return false }
+		}
+		cycles, err := m.GhostCycles(opts.Start, end)
+		if err != nil {
+			return res, fmt.Errorf("m.GhostCycles(): %w", err)
+		}
+		for _, c := range cycles {
+			edges, err := m.trace(m.Nodes[c.Start], c.CycleStart+c.CycleLen)
+			if err != nil {
+				return res, err
+			}
+			add(edges[c.CycleStart:], highlightColors[next%len(highlightColors)])
+			next++
+		}
+	}
+	return res, nil
+}
+
+// trace returns the edges followed in the first steps moves from start.
+func (m NodeMap) trace(start Node, steps int) ([]edge, error) {
+	edges := make([]edge, 0, steps)
+	n := start
+	for step := 0; step < steps; step++ {
+		lrIdx := step % len(m.LR)
+		edges = append(edges, edge{from: n.Name, dir: Direction(m.LR[lrIdx])})
+		next, err := m.step(n, lrIdx)
+		if err != nil {
+			return edges, err
+		}
+		n = next
+	}
+	return edges, nil
+}
+
+// adjacency is the JSON representation of a NodeMap.
+type adjacency struct {
+	Instructions string              `json:"instructions"`
+	Nodes        map[string]neighbor `json:"nodes"`
+}
+
+type neighbor struct {
+	Left  string `json:"L"`
+	Right string `json:"R"`
+}
+
+// MarshalJSON encodes the node map as its instructions and an adjacency list keyed by node name.
+//
+// Ex:
+//
+//	{"instructions":"LR","nodes":{"AAA":{"L":"BBB","R":"CCC"}}}
+func (m NodeMap) MarshalJSON() ([]byte, error) {
+	adj := adjacency{
+		Instructions: strings.Join(m.LR, ""),
+		Nodes:        make(map[string]neighbor, len(m.Nodes)),
+	}
+	for name, n := range m.Nodes {
+		adj.Nodes[name] = neighbor{Left: n.Left, Right: n.Right}
+	}
+	return json.Marshal(adj)
+}
+
+func (m *NodeMap) UnmarshalJSON(data []byte) error {
+	var adj adjacency
+	if err := json.Unmarshal(data, &adj); err != nil {
+		return err
+	}
+
+	nm := NodeMap{
+		LR:    strings.Split(adj.Instructions, ""),
+		Nodes: make(map[string]Node, len(adj.Nodes)),
+		lock:  &sync.Mutex{},
+	}
+	for name, n := range adj.Nodes {
+		if n.Left == "" || n.Right == "" {
+			return fmt.Errorf("node %q: missing left or right node", name)
+		}
+		nm.Nodes[name] = Node{Name: name, Left: n.Left, Right: n.Right}
+	}
+	if err := nm.Validate(); err != nil {
+		return err
+	}
+	*m = nm
+	return nil
+}
@@ -0,0 +1,88 @@
+package wasteland_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	wl "github.com/harveysanders/advent-of-code-2023/day08-haunted-wasteland"
+	"github.com/stretchr/testify/require"
+)
+
+const exportInput = `LLR
+
+AAA = (BBB, BBB)
+BBB = (AAA, ZZZ)
+ZZZ = (ZZZ, ZZZ)
+`
+
+func TestWriteDOT(t *testing.T) {
+	nodeMap, err := wl.ParseNodeMap(strings.NewReader(exportInput))
+	require.NoError(t, err)
+
+	var b strings.Builder
+	err = nodeMap.WriteDOT(&b, wl.DOTOptions{
+		Start: wl.Exact("AAA"),
+		End:   wl.Exact("ZZZ"),
+		Path:  true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, `digraph wasteland {
+	"AAA" [style=filled, fillcolor=palegreen];
+	"ZZZ" [style=filled, fillcolor=salmon];
+	"AAA" -> "BBB" [label="L", color=blue, fontcolor=blue, penwidth=2];
+	"AAA" -> "BBB" [label="R", color=blue, fontcolor=blue, penwidth=2];
+	"BBB" -> "AAA" [label="L", color=blue, fontcolor=blue, penwidth=2];
+	"BBB" -> "ZZZ" [label="R", color=blue, fontcolor=blue, penwidth=2];
+	"ZZZ" -> "ZZZ" [label="L"];
+	"ZZZ" -> "ZZZ" [label="R"];
+}
+`, b.String())
+}
+
+func TestWriteDOTCycles(t *testing.T) {
+	nodeMap, err := wl.ParseNodeMap(strings.NewReader(exportInput))
+	require.NoError(t, err)
+
+	var b strings.Builder
+	err = nodeMap.WriteDOT(&b, wl.DOTOptions{Start: wl.Exact("AAA"), Cycles: true})
+	require.NoError(t, err)
+
+	// The ghost ends up looping on ZZZ, so only those edges are part of its cycle.
+	out := b.String()
+	require.Contains(t, out, `"ZZZ" -> "ZZZ" [label="L", color=blue`)
+	require.Contains(t, out, `"ZZZ" -> "ZZZ" [label="R", color=blue`)
+	require.Contains(t, out, `"AAA" -> "BBB" [label="L"];`)
+
+	err = nodeMap.WriteDOT(&b, wl.DOTOptions{Path: true})
+	require.Error(t, err)
+}
+
+func TestMarshalJSON(t *testing.T) {
+	nodeMap, err := wl.ParseNodeMap(strings.NewReader(exportInput))
+	require.NoError(t, err)
+
+	data, err := json.Marshal(nodeMap)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"instructions": "LLR",
+		"nodes": {
+			"AAA": {"L": "BBB", "R": "BBB"},
+			"BBB": {"L": "AAA", "R": "ZZZ"},
+			"ZZZ": {"L": "ZZZ", "R": "ZZZ"}
+		}
+	}`, string(data))
+
+	var decoded wl.NodeMap
+	err = json.Unmarshal(data, &decoded)
+	require.NoError(t, err)
+	require.Equal(t, nodeMap.LR, decoded.LR)
+	require.Equal(t, nodeMap.Nodes, decoded.Nodes)
+
+	steps, err := decoded.TraverseSingle("AAA", "ZZZ")
+	require.NoError(t, err)
+	require.Equal(t, 6, steps)
+
+	err = json.Unmarshal([]byte(`{"instructions":"L","nodes":{"AAA":{"L":"AAA"}}}`), &decoded)
+	require.Error(t, err)
+}
@@ -0,0 +1,86 @@
+package wasteland
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Matcher selects nodes, e.g. where ghosts start or finish.
+type Matcher func(Node) bool
+
+// Prefix matches nodes whose name starts with prefix.
+func Prefix(prefix string) Matcher {
+	return func(n Node) bool { return strings.HasPrefix(n.Name, prefix) }
+}
+
+// Suffix matches nodes whose name ends with suffix, e.g. Suffix("Z").
+func Suffix(suffix string) Matcher {
+	return func(n Node) bool { return strings.HasSuffix(n.Name, suffix) }
+}
+
+// Exact matches the node with the name.
+func Exact(name string) Matcher {
+	return func(n Node) bool { return n.Name == name }
+}
+
+// OneOf matches any node with one of the names.
+func OneOf(names ...string) Matcher {
+	set := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		set[name] = struct{}{}
+	}
+	return func(n Node) bool {
+		_, ok := set[n.Name]
+		return ok
+	}
+}
+
+// Regex matches nodes whose name matches the regular expression.
+func Regex(expr string) (Matcher, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("regexp.Compile(): %w", err)
+	}
+	return func(n Node) bool { return re.MatchString(n.Name) }, nil
+}
+
+// ParsePattern creates a Matcher from a "kind:value" pattern. The kinds are:
+//
+//	prefix:XA     names starting with "XA"
+//	suffix:Z      names ending with "Z"
+//	exact:ZZZ     the node named "ZZZ"
+//	set:AAZ,BBZ   any of the comma-separated names
+//	regex:^..Z$   names matching the regular expression
+//
+// A pattern without a kind, like "ZZZ", is an exact name.
+func ParsePattern(pattern string) (Matcher, error) {
+	kind, value, ok := strings.Cut(pattern, ":")
+	if !ok {
+		return Exact(pattern), nil
+	}
+	switch kind {
+	case "prefix":
+		return Prefix(value), nil
+	case "suffix":
+		return Suffix(value), nil
+	case "exact":
+		return Exact(value), nil
+	case "set":
+		return OneOf(strings.Split(value, ",")...), nil
+	case "regex":
+		return Regex(value)
+	}
+	return nil, fmt.Errorf("unknown pattern kind %q in %q", kind, pattern)
+}
+
+// Matching returns the nodes selected by the matcher, sorted by name.
+func (m NodeMap) Matching(match Matcher) []Node {
+	nodes := []Node{}
+	for _, name := range m.names() {
+		if n := m.Nodes[name]; match(n) {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
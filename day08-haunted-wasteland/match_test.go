@@ -0,0 +1,81 @@
+package wasteland_test
+
+import (
+	"strings"
+	"testing"
+
+	wl "github.com/harveysanders/advent-of-code-2023/day08-haunted-wasteland"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePattern(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		match   []string
+		noMatch []string
+		wantErr bool
+	}{
+		{pattern: "ZZZ", match: []string{"ZZZ"}, noMatch: []string{"AZZ", "ZZ"}},
+		{pattern: "exact:ZZZ", match: []string{"ZZZ"}, noMatch: []string{"ZZZZ"}},
+		{pattern: "prefix:XA", match: []string{"XAB", "XA"}, noMatch: []string{"AXA"}},
+		{pattern: "suffix:Z", match: []string{"11Z", "ZZZ"}, noMatch: []string{"ZZA"}},
+		{pattern: "set:AAZ,BBZ", match: []string{"AAZ", "BBZ"}, noMatch: []string{"CCZ", "AAZ,BBZ"}},
+		{pattern: "regex:^[0-9]+Z$", match: []string{"11Z"}, noMatch: []string{"1AZ"}},
+		{pattern: "regex:[", wantErr: true},
+		{pattern: "glob:*Z", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.pattern, func(t *testing.T) {
+			match, err := wl.ParsePattern(tc.pattern)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for _, name := range tc.match {
+				require.True(t, match(wl.Node{Name: name}), name)
+			}
+			for _, name := range tc.noMatch {
+				require.False(t, match(wl.Node{Name: name}), name)
+			}
+		})
+	}
+}
+
+func TestPatternTraversal(t *testing.T) {
+	// Two ghosts start on nodes ending in XA; 33A doesn't match. The first ghost reaches 11Z every 2 steps and
+	// the second reaches 22Z every 3 steps, so they first finish together at step 6.
+	input := `LR
+
+11XA = (11B, XXX)
+11B = (XXX, 11Z)
+11Z = (11B, XXX)
+22XA = (22B, XXX)
+22B = (22C, 22C)
+22C = (22Z, 22Z)
+22Z = (22B, 22B)
+33A = (33A, 33A)
+XXX = (XXX, XXX)
+`
+	nodeMap, err := wl.ParseNodeMap(strings.NewReader(input))
+	require.NoError(t, err)
+
+	start := wl.Suffix("XA")
+	require.Len(t, nodeMap.Matching(start), 2)
+
+	steps, err := nodeMap.SolveGhosts(start, wl.OneOf("11Z", "22Z"))
+	require.NoError(t, err)
+	require.Equal(t, 6, steps)
+
+	steps, err = nodeMap.TraverseParallel(start, wl.OneOf("11Z", "22Z"))
+	require.NoError(t, err)
+	require.Equal(t, 6, steps)
+
+	steps, err = nodeMap.TraverseSingle("regex:^11X", "set:11Z,22Z")
+	require.NoError(t, err)
+	require.Equal(t, 2, steps)
+
+	_, err = nodeMap.TraverseSingle("suffix:XA", "11Z")
+	require.ErrorContains(t, err, "start matched 2 nodes")
+}
@@ -2,6 +2,7 @@ package wasteland
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -77,6 +78,9 @@ func ParseNodeMap(r io.Reader) (NodeMap, error) {
 
 		nm.Nodes[name] = node
 	}
+	if err := nm.Validate(); err != nil {
+		return nm, fmt.Errorf("nm.Validate(): %w", err)
+	}
 	return nm, nil
 }
 
@@ -88,24 +92,75 @@ func parseLRNodes(rawLRNodes string) (left, right string, err error) {
 	return lr[0], lr[1], nil
 }
 
+var (
+	// ErrUnreachable is returned when a walk enters a cycle that never reaches the destination.
+	ErrUnreachable = errors.New("destination unreachable")
+	// ErrStepLimit is returned when a walk takes more steps than allowed.
+	ErrStepLimit = errors.New("step limit reached")
+)
+
 // TraverseSingle uses the left/right instructions to move from start to end, returning the number of steps taken.
+// Start and end are patterns accepted by ParsePattern, so plain node names like "AAA" still work.
 func (m NodeMap) TraverseSingle(start, end string) (int, error) {
-	startNode := m.Nodes[start]
+	startMatch, err := ParsePattern(start)
+	if err != nil {
+		return 0, fmt.Errorf("ParsePattern(): %w", err)
+	}
+	endMatch, err := ParsePattern(end)
+	if err != nil {
+		return 0, fmt.Errorf("ParsePattern(): %w", err)
+	}
 
-	steps, err := m.move(startNode, end, 0)
+	steps, err := m.Walk(startMatch, endMatch, 0)
 	if err != nil {
-		return 0, fmt.Errorf("move: %w", err)
+		return 0, fmt.Errorf("m.Walk(): %w", err)
 	}
 
 	return steps, nil
 }
 
-func (m NodeMap) move(n Node, dest string, step int) (steps int, err error) {
-	if n.Name == dest {
-		return step, nil
+// Walk follows the left/right instructions from the single node matching start until it reaches a node matching
+// end, returning the number of steps taken. A maxSteps greater than zero limits the walk, returning ErrStepLimit when
+// it is reached. Because the next move only depends on the current node and instruction, the walk can't reach an end
+// once it repeats a (node, instruction) state. In that case, Walk returns an error wrapping ErrUnreachable that
+// describes the cycle.
+func (m NodeMap) Walk(start, end Matcher, maxSteps int) (int, error) {
+	if len(m.LR) == 0 {
+		return 0, fmt.Errorf("no left/right instructions")
+	}
+	starts := m.Matching(start)
+	if len(starts) != 1 {
+		return 0, fmt.Errorf("start matched %d nodes, want 1", len(starts))
+	}
+	n := starts[0]
+
+	seen := map[ghostState]int{}
+	for step := 0; ; step++ {
+		if end(n) {
+			return step, nil
+		}
+		if maxSteps > 0 && step >= maxSteps {
+			return step, fmt.Errorf("%w: %d steps from %q without reaching an end", ErrStepLimit, step, starts[0].Name)
+		}
+
+		state := ghostState{node: n.Name, lrIdx: step % len(m.LR)}
+		if first, ok := seen[state]; ok {
+			return step, fmt.Errorf("%w: from %q, entered a %d-step cycle at step %d (node %q, instruction %d)",
+				ErrUnreachable, starts[0].Name, step-first, first, state.node, state.lrIdx)
+		}
+		seen[state] = step
+
+		next, err := m.step(n, state.lrIdx)
+		if err != nil {
+			return step, err
+		}
+		n = next
 	}
+}
 
-	dir := Direction(m.LR[step%len(m.LR)])
+// step returns the node reached by following instruction lrIdx from n.
+func (m NodeMap) step(n Node, lrIdx int) (Node, error) {
+	dir := Direction(m.LR[lrIdx])
 	var ok bool
 	var next Node
 	switch dir {
@@ -113,12 +168,13 @@ func (m NodeMap) move(n Node, dest string, step int) (steps int, err error) {
 		next, ok = m.Nodes[n.Left]
 	case DirRight:
 		next, ok = m.Nodes[n.Right]
+	default:
+		return next, fmt.Errorf("invalid direction: %q", dir)
 	}
 	if !ok {
-		return step, fmt.Errorf("node not found: %+v, dir: %q", n, dir)
+		return next, fmt.Errorf("node not found: %+v, dir: %q", n, dir)
 	}
-
-	return m.move(next, dest, step+1)
+	return next, nil
 }
 
 func (m *NodeMap) moveGhost(idx int) {
@@ -140,22 +196,21 @@ func (m *NodeMap) moveGhost(idx int) {
 	m.lock.Lock()
 	m.ghosts[idx] = g
 	m.lock.Unlock()
-
-	if strings.HasSuffix(next.Name, "Z") {
-		log.Printf("ghost %d at end %q\n", idx, next.Name)
-	}
 }
 
-func (n *NodeMap) TraverseParallel(start, end string) (int, error) {
+// TraverseParallel moves a ghost from every node matching start, one step at a time, until every ghost is on a node
+// matching end. It can take far too long on real inputs; use SolveGhosts instead.
+func (n *NodeMap) TraverseParallel(start, end Matcher) (int, error) {
 	n.ghosts = make([]ghost, 0)
-	for name, node := range n.Nodes {
-		if strings.HasSuffix(name, start) {
-			n.ghosts = append(n.ghosts, ghost{curNode: node})
-		}
+	for _, node := range n.Matching(start) {
+		n.ghosts = append(n.ghosts, ghost{curNode: node})
+	}
+	if len(n.ghosts) == 0 {
+		return 0, fmt.Errorf("no start nodes matched")
 	}
 
 	// Spin up a go routine for each of the start nodes
-	// run them each one step at a time until they all are on a node that ends with the end parameter ("Z")
+	// run them each one step at a time until they all are on a node that matches end
 	for {
 		var wg sync.WaitGroup
 		for i := range n.ghosts {
@@ -169,14 +224,11 @@ func (n *NodeMap) TraverseParallel(start, end string) (int, error) {
 		wg.Wait()
 
 		allDone := true
-		for i, v := range n.ghosts {
-			if !strings.HasSuffix(v.curNode.Name, "Z") {
+		for _, v := range n.ghosts {
+			if !end(v.curNode) {
 				allDone = false
 				break
 			}
-			if i > 0 {
-				log.Printf("ghost %d of %d, step: %d, node: %q\n", i, len(n.ghosts), v.curStep, v.curNode.Name)
-			}
 		}
 		if allDone {
 			return n.ghosts[0].curStep, nil
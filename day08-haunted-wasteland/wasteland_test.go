@@ -1,6 +1,7 @@
 package wasteland_test
 
 import (
+	"fmt"
 	"io"
 	"os"
 	"strings"
@@ -127,10 +128,77 @@ XXX = (XXX, XXX)
 			nodeMap, err := wl.ParseNodeMap(tc.input)
 			require.NoError(t, err)
 
-			gotSteps, err := nodeMap.TraverseParallel("A", "Z")
+			gotSteps, err := nodeMap.TraverseParallel(wl.Suffix("A"), wl.Suffix("Z"))
 			require.NoError(t, err)
 			require.Equal(t, tc.wantSteps, gotSteps)
 		})
 
 	}
 }
+
+func TestWalk(t *testing.T) {
+	input := `RL
+
+AAA = (BBB, CCC)
+BBB = (DDD, EEE)
+CCC = (ZZZ, GGG)
+DDD = (DDD, DDD)
+EEE = (EEE, EEE)
+GGG = (GGG, GGG)
+ZZZ = (ZZZ, ZZZ)
+`
+	nodeMap, err := wl.ParseNodeMap(strings.NewReader(input))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name      string
+		start     string
+		end       string
+		maxSteps  int
+		wantSteps int
+		wantErr   error
+	}{
+		{name: "reachable", start: "AAA", end: "ZZZ", wantSteps: 2},
+		{name: "already there", start: "ZZZ", end: "ZZZ", wantSteps: 0},
+		{name: "within limit", start: "AAA", end: "ZZZ", maxSteps: 2, wantSteps: 2},
+		{name: "over limit", start: "AAA", end: "ZZZ", maxSteps: 1, wantErr: wl.ErrStepLimit},
+		{name: "stuck in self-loop", start: "BBB", end: "ZZZ", wantErr: wl.ErrUnreachable},
+		{name: "unknown destination", start: "AAA", end: "QQQ", wantErr: wl.ErrUnreachable},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotSteps, err := nodeMap.Walk(wl.Exact(tc.start), wl.Exact(tc.end), tc.maxSteps)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantSteps, gotSteps)
+		})
+	}
+
+	_, err = nodeMap.Walk(wl.Exact("BBB"), wl.Exact("ZZZ"), 0)
+	require.ErrorContains(t, err, `2-step cycle at step 1 (node "EEE", instruction 1)`)
+
+	_, err = nodeMap.Walk(wl.Exact("XXX"), wl.Exact("ZZZ"), 0)
+	require.Error(t, err)
+}
+
+func TestWalkLongPath(t *testing.T) {
+	// A long chain of nodes, one step each, so the walk is as deep as the answer.
+	var input strings.Builder
+	input.WriteString("L\n\n")
+	const n = 100_000
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&input, "N%d = (N%d, N%d)\n", i, i+1, i+1)
+	}
+	fmt.Fprintf(&input, "N%d = (N%d, N%d)\n", n, n, n)
+
+	nodeMap, err := wl.ParseNodeMap(strings.NewReader(input.String()))
+	require.NoError(t, err)
+
+	gotSteps, err := nodeMap.TraverseSingle("N0", fmt.Sprintf("N%d", n))
+	require.NoError(t, err)
+	require.Equal(t, n, gotSteps)
+}
@@ -0,0 +1,118 @@
+package oasis
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Approximation is a least-squares polynomial fit to a history, for histories that don't reduce to zeros.
+type Approximation struct {
+	Degree       int
+	Coefficients []*big.Rat // Power basis coefficients, constant term first, with the first value at x = 0.
+	Residuals    []*big.Rat // Each history value minus the fitted value at its position.
+}
+
+// At evaluates the fitted polynomial at x.
+func (a Approximation) At(x int) *big.Rat {
+	res := new(big.Rat)
+	bx := new(big.Rat).SetInt64(int64(x))
+	// Horner's method, from the highest power down.
+	for i := len(a.Coefficients) - 1; i >= 0; i-- {
+		res.Mul(res, bx).Add(res, a.Coefficients[i])
+	}
+	return res
+}
+
+// MaxResidual returns the largest absolute residual.
+func (a Approximation) MaxResidual() float64 {
+	res := 0.0
+	for _, r := range a.Residuals {
+		f, _ := new(big.Rat).Abs(r).Float64()
+		res = max(res, f)
+	}
+	return res
+}
+
+// Approximate fits the lowest-degree polynomial whose residuals are all within tolerance of the history.
+// Unlike Fit, it always succeeds for a non-empty history: at worst, a polynomial of degree len(history)-1 passes
+// through every value. The fit is exact, using rational arithmetic.
+func (m Measurement) Approximate(tolerance float64) (Approximation, error) {
+	values := m.bigValues()
+	if len(values) == 0 {
+		return Approximation{}, fmt.Errorf("empty history")
+	}
+	for degree := 0; ; degree++ {
+		a := leastSquares(values, degree)
+		if a.MaxResidual() <= tolerance || degree == len(values)-1 {
+			return a, nil
+		}
+	}
+}
+
+// leastSquares fits a polynomial of the degree to the values at x = 0, 1, 2, ... by solving the normal equations.
+// The degree must be less than the number of values, so the system has a unique solution.
+func leastSquares(ys []*big.Int, degree int) Approximation {
+	size := degree + 1
+
+	// powers[x][i] = x^i, up to the highest power needed by the normal equations.
+	powers := make([][]*big.Rat, len(ys))
+	for x := range ys {
+		powers[x] = make([]*big.Rat, 2*size-1)
+		powers[x][0] = big.NewRat(1, 1)
+		for i := 1; i < len(powers[x]); i++ {
+			powers[x][i] = new(big.Rat).Mul(powers[x][i-1], big.NewRat(int64(x), 1))
+		}
+	}
+
+	// Augmented matrix [A | b] where A[i][j] = sum(x^(i+j)) and b[i] = sum(x^i * y).
+	rows := make([][]*big.Rat, size)
+	for i := range rows {
+		rows[i] = make([]*big.Rat, size+1)
+		for j := range rows[i] {
+			rows[i][j] = new(big.Rat)
+		}
+		for x, y := range ys {
+			for j := 0; j < size; j++ {
+				rows[i][j].Add(rows[i][j], powers[x][i+j])
+			}
+			term := new(big.Rat).Mul(powers[x][i], new(big.Rat).SetInt(y))
+			rows[i][size].Add(rows[i][size], term)
+		}
+	}
+
+	// Gauss-Jordan elimination. The matrix is positive definite, so a non-zero pivot always exists.
+	for col := 0; col < size; col++ {
+		pivot := col
+		for pivot < size && rows[pivot][col].Sign() == 0 {
+			pivot++
+		}
+		rows[col], rows[pivot] = rows[pivot], rows[col]
+
+		inv := new(big.Rat).Inv(rows[col][col])
+		for j := col; j <= size; j++ {
+			rows[col][j].Mul(rows[col][j], inv)
+		}
+		for i := range rows {
+			if i == col || rows[i][col].Sign() == 0 {
+				continue
+			}
+			factor := new(big.Rat).Set(rows[i][col])
+			for j := col; j <= size; j++ {
+				rows[i][j].Sub(rows[i][j], new(big.Rat).Mul(factor, rows[col][j]))
+			}
+		}
+	}
+
+	a := Approximation{
+		Degree:       degree,
+		Coefficients: make([]*big.Rat, size),
+		Residuals:    make([]*big.Rat, len(ys)),
+	}
+	for i := range a.Coefficients {
+		a.Coefficients[i] = rows[i][size]
+	}
+	for x, y := range ys {
+		a.Residuals[x] = new(big.Rat).Sub(new(big.Rat).SetInt(y), a.At(x))
+	}
+	return a
+}
@@ -0,0 +1,78 @@
+package oasis_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	oasis "github.com/harveysanders/advent-of-code-2023/day09-mirage-maintenance"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotPolynomial(t *testing.T) {
+	// Powers of two never reduce to zeros: each row of differences is the same as the one above it.
+	report, err := oasis.ParseReport(strings.NewReader(`1 2 4 8 16 32
+0 0 0
+5
+`))
+	require.NoError(t, err)
+
+	_, err = report.Measurements[0].Extrapolate()
+	require.ErrorIs(t, err, oasis.ErrNotPolynomial)
+
+	_, err = report.Total(1)
+	require.ErrorIs(t, err, oasis.ErrNotPolynomial)
+	require.ErrorContains(t, err, "measurement 0")
+
+	got, err := report.Measurements[1].Extrapolate()
+	require.NoError(t, err)
+	require.Equal(t, 0, got)
+
+	// A single value has no differences to check.
+	_, err = report.Measurements[2].Extrapolate()
+	require.ErrorIs(t, err, oasis.ErrNotPolynomial)
+}
+
+func TestApproximate(t *testing.T) {
+	report, err := oasis.ParseReport(strings.NewReader(`1 2 4 8 16 32
+0 3 6 9 12 15
+0 1 0 1 0 1
+`))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		measure    int
+		tolerance  float64
+		wantDegree int
+	}{
+		{name: "exact line", measure: 1, tolerance: 0, wantDegree: 1},
+		{name: "powers of two, exact", measure: 0, tolerance: 0, wantDegree: 5},
+		{name: "powers of two, loose", measure: 0, tolerance: 1, wantDegree: 3},
+		{name: "alternating, loose", measure: 2, tolerance: 0.5, wantDegree: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := report.Measurements[tc.measure]
+			a, err := m.Approximate(tc.tolerance)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantDegree, a.Degree)
+			require.Len(t, a.Residuals, 6)
+			require.LessOrEqual(t, a.MaxResidual(), tc.tolerance)
+		})
+	}
+
+	// The best constant for 0 1 0 1 0 1 is the mean, leaving every value 1/2 away.
+	a, err := report.Measurements[2].Approximate(0.5)
+	require.NoError(t, err)
+	require.Zero(t, big.NewRat(1, 2).Cmp(a.Coefficients[0]), a.Coefficients[0].RatString())
+	for _, r := range a.Residuals {
+		require.Zero(t, big.NewRat(1, 2).Cmp(new(big.Rat).Abs(r)), r.RatString())
+	}
+
+	// An exact fit predicts the same value as Extrapolate.
+	a, err = report.Measurements[1].Approximate(0)
+	require.NoError(t, err)
+	require.Equal(t, "18", a.At(6).RatString())
+}
@@ -0,0 +1,198 @@
+package oasis
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrOverflow is returned when an exact result doesn't fit in an int.
+var ErrOverflow = errors.New("value overflows int")
+
+type ReportOption func(*Report)
+
+// WithBig parses values as big.Int and does all arithmetic exactly, however large the values get.
+// Int results, like those from Predict and Total, return an error wrapping ErrOverflow if they don't fit;
+// use PredictBig and TotalBig to get them exactly.
+func WithBig() ReportOption {
+	return func(r *Report) {
+		r.exact = true
+	}
+}
+
+// BigPolynomial is a Polynomial with arbitrary-precision differences.
+type BigPolynomial struct {
+	Degree      int
+	Differences []*big.Int
+}
+
+// At evaluates the polynomial at x.
+func (p BigPolynomial) At(x *big.Int) *big.Int {
+	res := new(big.Int)
+	binom := big.NewInt(1) // C(x, j)
+	for j, d := range p.Differences {
+		res.Add(res, new(big.Int).Mul(d, binom))
+		// C(x, j+1) = C(x, j) * (x-j) / (j+1), which always divides exactly.
+		binom.Mul(binom, new(big.Int).Sub(x, big.NewInt(int64(j))))
+		binom.Quo(binom, big.NewInt(int64(j+1)))
+	}
+	return res
+}
+
+// AtRat evaluates the polynomial at a position that may fall between the history's values.
+func (p BigPolynomial) AtRat(x *big.Rat) *big.Rat {
+	res := new(big.Rat)
+	binom := big.NewRat(1, 1) // C(x, j)
+	for j, d := range p.Differences {
+		res.Add(res, new(big.Rat).Mul(new(big.Rat).SetInt(d), binom))
+		binom.Mul(binom, new(big.Rat).Sub(x, big.NewRat(int64(j), 1)))
+		binom.Quo(binom, big.NewRat(int64(j+1), 1))
+	}
+	return res
+}
+
+// Coefficients returns the polynomial's coefficients in the usual power basis, constant term first.
+func (p BigPolynomial) Coefficients() []*big.Rat {
+	coeffs := make([]*big.Rat, p.Degree+1)
+	for i := range coeffs {
+		coeffs[i] = new(big.Rat)
+	}
+
+	// falling holds the power basis coefficients of x(x-1)...(x-j+1), starting with 1.
+	falling := []*big.Int{big.NewInt(1)}
+	factorial := big.NewInt(1)
+	for j, d := range p.Differences {
+		if j > 0 {
+			factorial.Mul(factorial, big.NewInt(int64(j)))
+		}
+		for i, f := range falling {
+			term := new(big.Rat).SetFrac(new(big.Int).Mul(f, d), factorial)
+			coeffs[i].Add(coeffs[i], term)
+		}
+
+		// Multiply falling by (x - j).
+		next := make([]*big.Int, len(falling)+1)
+		for i := range next {
+			next[i] = new(big.Int)
+		}
+		for i, f := range falling {
+			next[i+1].Add(next[i+1], f)
+			next[i].Sub(next[i], new(big.Int).Mul(f, big.NewInt(int64(j))))
+		}
+		falling = next
+	}
+	return coeffs
+}
+
+// Int converts the polynomial to int differences, returning an error wrapping ErrOverflow if one doesn't fit.
+func (p BigPolynomial) Int() (Polynomial, error) {
+	res := Polynomial{Degree: p.Degree, Differences: make([]int, len(p.Differences))}
+	for i, d := range p.Differences {
+		v, err := toInt(d)
+		if err != nil {
+			return res, fmt.Errorf("difference %d: %w", i, err)
+		}
+		res.Differences[i] = v
+	}
+	return res, nil
+}
+
+// Big converts the polynomial to arbitrary precision.
+func (p Polynomial) Big() BigPolynomial {
+	b := BigPolynomial{Degree: p.Degree, Differences: make([]*big.Int, len(p.Differences))}
+	for i, d := range p.Differences {
+		b.Differences[i] = big.NewInt(int64(d))
+	}
+	return b
+}
+
+// FitBig is like Fit but works in arbitrary precision, so the differences can't overflow.
+func (m Measurement) FitBig() (BigPolynomial, error) {
+	p := BigPolynomial{Differences: []*big.Int{}}
+	row := m.bigValues()
+	if len(row) == 0 {
+		return p, fmt.Errorf("empty history")
+	}
+	for len(row) > 0 && !allZerosBig(row) {
+		p.Differences = append(p.Differences, new(big.Int).Set(row[0]))
+		next := make([]*big.Int, len(row)-1)
+		for i := range next {
+			next[i] = new(big.Int).Sub(row[i+1], row[i])
+		}
+		row = next
+	}
+	p.Degree = max(len(p.Differences)-1, 0)
+	if len(row) == 0 {
+		return p, fmt.Errorf("%w: %d values fit a degree %d polynomial exactly", ErrNotPolynomial, m.len(), p.Degree)
+	}
+	return p, nil
+}
+
+// PredictBig is like Predict but returns the exact value, however large.
+func (m Measurement) PredictBig(k int) (*big.Int, BigPolynomial, error) {
+	p, err := m.FitBig()
+	if err != nil {
+		return nil, p, err
+	}
+	x := big.NewInt(int64(m.len() - 1 + k))
+	return p.At(x), p, nil
+}
+
+// Interpolate evaluates the polynomial fitted to the history at position x, where the first value is at 0.
+// x can fall between values, e.g. 1/2 is halfway between the first and second values.
+func (m Measurement) Interpolate(x *big.Rat) (*big.Rat, error) {
+	p, err := m.FitBig()
+	if err != nil {
+		return nil, err
+	}
+	return p.AtRat(x), nil
+}
+
+// TotalBig is like Total but returns the exact sum, however large.
+func (r Report) TotalBig(k int) (*big.Int, error) {
+	sum := new(big.Int)
+	for i, m := range r.Measurements {
+		v, _, err := m.PredictBig(k)
+		if err != nil {
+			return nil, fmt.Errorf("measurement %d: %w", i, err)
+		}
+		sum.Add(sum, v)
+	}
+	return sum, nil
+}
+
+// bigValues returns the history in arbitrary precision.
+func (m Measurement) bigValues() []*big.Int {
+	if m.values != nil {
+		return m.values
+	}
+	values := make([]*big.Int, len(m.history))
+	for i, v := range m.history {
+		values[i] = big.NewInt(int64(v))
+	}
+	return values
+}
+
+func (m Measurement) len() int {
+	if m.values != nil {
+		return len(m.values)
+	}
+	return len(m.history)
+}
+
+func allZerosBig(list []*big.Int) bool {
+	for _, v := range list {
+		if v.Sign() != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// toInt converts an exact result to an int, returning an error wrapping ErrOverflow if it doesn't fit.
+func toInt(v *big.Int) (int, error) {
+	if !v.IsInt64() {
+		return 0, fmt.Errorf("%w: %s", ErrOverflow, v)
+	}
+	return int(v.Int64()), nil
+}
@@ -0,0 +1,128 @@
+package oasis_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	oasis "github.com/harveysanders/advent-of-code-2023/day09-mirage-maintenance"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithBig(t *testing.T) {
+	sample := `0 3 6 9 12 15
+1 3 6 10 15 21
+10 13 16 21 30 45
+`
+	report, err := oasis.ParseReport(strings.NewReader(sample), oasis.WithBig())
+	require.NoError(t, err)
+
+	total, err := report.Total(1)
+	require.NoError(t, err)
+	require.Equal(t, 114, total)
+
+	total, err = report.TotalReverse()
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+
+	bigTotal, err := report.TotalBig(1)
+	require.NoError(t, err)
+	require.Equal(t, "114", bigTotal.String())
+}
+
+func TestWithBigHugeValues(t *testing.T) {
+	input := "100000000000000000000 200000000000000000000 300000000000000000000\n"
+
+	_, err := oasis.ParseReport(strings.NewReader(input))
+	require.Error(t, err)
+
+	report, err := oasis.ParseReport(strings.NewReader(input), oasis.WithBig())
+	require.NoError(t, err)
+
+	got, p, err := report.Measurements[0].PredictBig(1)
+	require.NoError(t, err)
+	require.Equal(t, "400000000000000000000", got.String())
+	require.Equal(t, 1, p.Degree)
+
+	_, err = report.Measurements[0].Extrapolate()
+	require.ErrorIs(t, err, oasis.ErrOverflow)
+}
+
+func TestPredictBigFarAhead(t *testing.T) {
+	// x^5 for x = 0..6.
+	report, err := oasis.ParseReport(strings.NewReader("0 1 32 243 1024 3125 7776\n"), oasis.WithBig())
+	require.NoError(t, err)
+	m := report.Measurements[0]
+
+	const k = 1_000_000
+	got, p, err := m.PredictBig(k)
+	require.NoError(t, err)
+	require.Equal(t, 5, p.Degree)
+
+	x := big.NewInt(k + 6)
+	want := new(big.Int).Exp(x, big.NewInt(5), nil)
+	require.Zero(t, want.Cmp(got), "got %s, want %s", got, want)
+
+	_, _, err = m.Predict(k)
+	require.ErrorIs(t, err, oasis.ErrOverflow)
+}
+
+func TestPredictBigDifferencesOverflow(t *testing.T) {
+	// 5 - 10^19 * x(x-2) for x = 0..3. The value at x = 2 fits in an int, but the differences don't.
+	report, err := oasis.ParseReport(strings.NewReader("5 10000000000000000005 5 -29999999999999999995\n"), oasis.WithBig())
+	require.NoError(t, err)
+	m := report.Measurements[0]
+
+	got, p, err := m.Predict(-1)
+	require.NoError(t, err)
+	require.Equal(t, 5, got)
+	require.Equal(t, 2, p.Degree)
+	require.Nil(t, p.Differences)
+
+	_, bp, err := m.PredictBig(-1)
+	require.NoError(t, err)
+	require.Equal(t, "-20000000000000000000", bp.Differences[2].String())
+
+	_, err = m.Fit()
+	require.ErrorIs(t, err, oasis.ErrOverflow)
+}
+
+func TestFitBigCopiesHistory(t *testing.T) {
+	report, err := oasis.ParseReport(strings.NewReader("7 7 7\n"), oasis.WithBig())
+	require.NoError(t, err)
+	m := report.Measurements[0]
+
+	p, err := m.FitBig()
+	require.NoError(t, err)
+	p.Differences[0].SetInt64(42)
+
+	got, err := m.Extrapolate()
+	require.NoError(t, err)
+	require.Equal(t, 7, got)
+}
+
+func TestInterpolate(t *testing.T) {
+	// (x+1)(x+2)/2 for x = 0..5.
+	report, err := oasis.ParseReport(strings.NewReader("1 3 6 10 15 21\n"), oasis.WithBig())
+	require.NoError(t, err)
+
+	testCases := []struct {
+		x    string
+		want string
+	}{
+		{x: "0", want: "1"},
+		{x: "1/2", want: "15/8"},
+		{x: "-3/2", want: "-1/8"},
+		{x: "6", want: "28"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.x, func(t *testing.T) {
+			x, ok := new(big.Rat).SetString(tc.x)
+			require.True(t, ok)
+			got, err := report.Measurements[0].Interpolate(x)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got.RatString())
+		})
+	}
+}
@@ -2,73 +2,128 @@ package oasis
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"strconv"
 	"strings"
 )
 
+// ErrNotPolynomial is returned when a history's differences never reduce to a row of zeros, so there is no evidence
+// that it follows a polynomial. Any n values fit a polynomial of degree n-1, so extrapolating would give an answer
+// that only looks confident. Use Measurement.Approximate for a best-effort fit instead.
+var ErrNotPolynomial = errors.New("history does not reduce to zeros")
+
 type Report struct {
 	Measurements []Measurement
+
+	exact bool // Values are parsed and extrapolated as big.Int. Set with WithBig.
+}
+
+// Total sums the prediction at offset k for each measurement. See Measurement.Predict for how k is interpreted.
+// Use TotalReverse to sum the values before each history, since those are at a different k for each history length.
+//
+// Ex:
+//
+//	report.Total(1) // Sum of the next values.
+func (r Report) Total(k int) (int, error) {
+	sum := 0
+	for i, m := range r.Measurements {
+		v, _, err := m.Predict(k)
+		if err != nil {
+			return 0, fmt.Errorf("measurement %d: %w", i, err)
+		}
+		sum += v
+	}
+	return sum, nil
 }
 
-func (r Report) Total(reverse bool) int {
+// TotalReverse sums the value before the start of each history.
+func (r Report) TotalReverse() (int, error) {
 	sum := 0
-	for _, m := range r.Measurements {
-		if reverse {
-			sum += m.ExtrapolateReverse()
-		} else {
-			sum += m.Extrapolate()
+	for i, m := range r.Measurements {
+		v, err := m.ExtrapolateReverse()
+		if err != nil {
+			return 0, fmt.Errorf("measurement %d: %w", i, err)
 		}
+		sum += v
 	}
-	return sum
+	return sum, nil
 }
 
 type Measurement struct {
 	history []int
+	values  []*big.Int // Set instead of history when the report uses big.Int arithmetic.
 }
 
-func (m Measurement) Extrapolate() int {
-	return m.extrapolate(false)
+// Extrapolate predicts the next value of the history.
+func (m Measurement) Extrapolate() (int, error) {
+	v, _, err := m.Predict(1)
+	return v, err
 }
 
-func (m Measurement) ExtrapolateReverse() int {
-	return m.extrapolate(true)
+// ExtrapolateReverse predicts the value before the start of the history.
+func (m Measurement) ExtrapolateReverse() (int, error) {
+	v, _, err := m.Predict(-m.len())
+	return v, err
 }
 
-func (m Measurement) extrapolate(reverse bool) int {
-	list := m.history
-	allDiffs := [][]int{list}
-	isStart := true
-	for isStart || !allZeros(list) {
-		isStart = false
-		list = diffEach(list)
-		allDiffs = append(allDiffs, list)
+// Predict fits a polynomial to the history and evaluates it k steps from the last value. Predict(0) is the last value,
+// Predict(1) is the next value and Predict(-1) is the second-to-last value, so the value before the history starts
+// is at -len(history). The fitted polynomial is returned with the value. With WithBig, if the polynomial's differences
+// overflow an int even though the value fits, only its Degree is set and its Differences are nil; callers that need
+// the polynomial must use PredictBig.
+func (m Measurement) Predict(k int) (int, Polynomial, error) {
+	if m.values != nil {
+		bv, bp, err := m.PredictBig(k)
+		if err != nil {
+			return 0, Polynomial{}, err
+		}
+		v, err := toInt(bv)
+		if err != nil {
+			return 0, Polynomial{}, err
+		}
+		p, err := bp.Int()
+		if err != nil {
+			return v, Polynomial{Degree: bp.Degree}, nil
+		}
+		return v, p, nil
 	}
-	if reverse {
-		return calcFirstValues(allDiffs)
+
+	p, err := m.Fit()
+	if err != nil {
+		return 0, p, err
 	}
-	return calcLastValues(allDiffs)
+	return p.At(len(m.history) - 1 + k), p, nil
 }
 
-func calcFirstValues(allDiffs [][]int) int {
-	values := []int{0}
-	for i := len(allDiffs) - 1; i > -1; i-- {
-		diffs := allDiffs[i]
-		v := diffs[0] - values[len(allDiffs)-1-i]
-		values = append(values, v)
+// Fit returns the lowest-degree polynomial through the history, with the first value at x = 0.
+// It only keeps the current row of the difference table, collecting the Newton forward differences on the way.
+// The returned error wraps ErrNotPolynomial if the rows run out before one is all zeros.
+func (m Measurement) Fit() (Polynomial, error) {
+	if m.values != nil {
+		bp, err := m.FitBig()
+		if err != nil {
+			return Polynomial{}, err
+		}
+		return bp.Int()
 	}
-	return values[len(values)-1]
-}
 
-func calcLastValues(allDiffs [][]int) int {
-	values := []int{0}
-	for i := len(allDiffs) - 1; i > -1; i-- {
-		diffs := allDiffs[i]
-		v := diffs[len(diffs)-1] + values[len(allDiffs)-1-i]
-		values = append(values, v)
+	p := Polynomial{Differences: []int{}}
+	if len(m.history) == 0 {
+		return p, fmt.Errorf("empty history")
+	}
+	row := m.history
+	for len(row) > 0 && !allZeros(row) {
+		p.Differences = append(p.Differences, row[0])
+		row = diffEach(row)
+	}
+	p.Degree = max(len(p.Differences)-1, 0)
+	if len(row) == 0 {
+		return p, fmt.Errorf("%w: %d values fit a degree %d polynomial exactly", ErrNotPolynomial, len(m.history), p.Degree)
 	}
-	return values[len(values)-1]
+	return p, nil
 }
 
 func allZeros(list []int) bool {
@@ -90,9 +145,14 @@ func diffEach(list []int) []int {
 	return diffs
 }
 
-func ParseReport(r io.Reader) (Report, error) {
+// ParseReport parses one history of space-separated values per line.
+// By default, values are ints; pass WithBig for exact arithmetic on values of any size.
+func ParseReport(r io.Reader, opts ...ReportOption) (Report, error) {
 	scr := bufio.NewScanner(r)
 	report := Report{Measurements: []Measurement{}}
+	for _, o := range opts {
+		o(&report)
+	}
 	for scr.Scan() {
 		if scr.Err() != nil {
 			return report, fmt.Errorf("scr.Err(): %w", scr.Err())
@@ -100,6 +160,19 @@ func ParseReport(r io.Reader) (Report, error) {
 
 		line := scr.Text()
 		raw := strings.Fields(line)
+		if report.exact {
+			values := make([]*big.Int, len(raw))
+			for i, v := range raw {
+				n, ok := new(big.Int).SetString(v, 10)
+				if !ok {
+					return report, fmt.Errorf("invalid value: %q", v)
+				}
+				values[i] = n
+			}
+			report.Measurements = append(report.Measurements, Measurement{values: values})
+			continue
+		}
+
 		history := make([]int, len(raw))
 		for i, v := range raw {
 			n, err := strconv.Atoi(v)
@@ -22,7 +22,8 @@ func TestExtrapolate(t *testing.T) {
 	wantExtrapolatedValues := []int{18, 28, 68}
 
 	for i, wantV := range wantExtrapolatedValues {
-		got := report.Measurements[i].Extrapolate()
+		got, err := report.Measurements[i].Extrapolate()
+		require.NoError(t, err)
 		require.Equal(t, wantV, got)
 	}
 }
@@ -37,7 +38,8 @@ func TestExtrapolateReverse(t *testing.T) {
 
 	wantExtrapolatedValues := []int{-3, 0, 5}
 	for i, wantV := range wantExtrapolatedValues {
-		got := report.Measurements[i].ExtrapolateReverse()
+		got, err := report.Measurements[i].ExtrapolateReverse()
+		require.NoError(t, err)
 		require.Equal(t, wantV, got)
 	}
 }
@@ -90,8 +92,23 @@ func TestTotal(t *testing.T) {
 			report, err := oasis.ParseReport(tc.input)
 			require.NoError(t, err)
 
-			got := report.Total(tc.reverseExtrapolation)
+			got, err := report.Total(1)
+			if tc.reverseExtrapolation {
+				got, err = report.TotalReverse()
+			}
+			require.NoError(t, err)
 			require.Equal(t, tc.wantTotal, got)
 		})
 	}
 }
+
+func TestTotalReverseDifferentLengths(t *testing.T) {
+	report, err := oasis.ParseReport(strings.NewReader(`0 3 6 9 12 15
+1 3 6 10 15 21 28
+`))
+	require.NoError(t, err)
+
+	got, err := report.TotalReverse()
+	require.NoError(t, err)
+	require.Equal(t, -3, got)
+}
@@ -0,0 +1,35 @@
+package oasis
+
+import "math/big"
+
+// Polynomial is a polynomial in Newton forward difference form:
+//
+//	p(x) = Differences[0] + Differences[1]*C(x, 1) + ... + Differences[Degree]*C(x, Degree)
+//
+// where C(x, j) = x(x-1)...(x-j+1) / j!. For a history fitted with the first value at x = 0,
+// Differences[j] is the first value of the j-th row of its difference table.
+type Polynomial struct {
+	Degree      int
+	Differences []int
+}
+
+// At evaluates the polynomial at x. x may be negative or beyond the fitted history.
+func (p Polynomial) At(x int) int {
+	res := 0
+	binom := 1 // C(x, j)
+	for j, d := range p.Differences {
+		res += d * binom
+		// C(x, j+1) = C(x, j) * (x-j) / (j+1), which always divides exactly.
+		binom = binom * (x - j) / (j + 1)
+	}
+	return res
+}
+
+// Coefficients returns the polynomial's coefficients in the usual power basis, constant term first:
+//
+//	p(x) = c[0] + c[1]*x + c[2]*x^2 + ...
+//
+// The coefficients can be fractions, e.g. the triangular numbers are x^2/2 + x/2.
+func (p Polynomial) Coefficients() []*big.Rat {
+	return p.Big().Coefficients()
+}
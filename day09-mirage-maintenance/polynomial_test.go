@@ -0,0 +1,77 @@
+package oasis_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	oasis "github.com/harveysanders/advent-of-code-2023/day09-mirage-maintenance"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPredict(t *testing.T) {
+	report, err := oasis.ParseReport(strings.NewReader(`0 3 6 9 12 15
+1 3 6 10 15 21
+10 13 16 21 30 45
+`))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		measure    int
+		k          int
+		want       int
+		wantDegree int
+	}{
+		{name: "linear next", measure: 0, k: 1, want: 18, wantDegree: 1},
+		{name: "linear last", measure: 0, k: 0, want: 15, wantDegree: 1},
+		{name: "linear second to last", measure: 0, k: -1, want: 12, wantDegree: 1},
+		{name: "linear first", measure: 0, k: -5, want: 0, wantDegree: 1},
+		{name: "linear previous", measure: 0, k: -6, want: -3, wantDegree: 1},
+		{name: "linear far ahead", measure: 0, k: 10, want: 45, wantDegree: 1},
+		{name: "triangular next", measure: 1, k: 1, want: 28, wantDegree: 2},
+		{name: "triangular far ahead", measure: 1, k: 100, want: 5671, wantDegree: 2},
+		{name: "triangular far behind", measure: 1, k: -8, want: 1, wantDegree: 2},
+		{name: "cubic next", measure: 2, k: 1, want: 68, wantDegree: 3},
+		{name: "cubic previous", measure: 2, k: -6, want: 5, wantDegree: 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, p, err := report.Measurements[tc.measure].Predict(tc.k)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+			require.Equal(t, tc.wantDegree, p.Degree)
+		})
+	}
+}
+
+func TestCoefficients(t *testing.T) {
+	report, err := oasis.ParseReport(strings.NewReader(`0 3 6 9 12 15
+1 3 6 10 15 21
+7 7 7
+`))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		measure int
+		want    []string
+	}{
+		{measure: 0, want: []string{"0", "3"}},
+		// (x+1)(x+2)/2
+		{measure: 1, want: []string{"1", "3/2", "1/2"}},
+		{measure: 2, want: []string{"7"}},
+	}
+
+	for _, tc := range testCases {
+		p, err := report.Measurements[tc.measure].Fit()
+		require.NoError(t, err)
+		got := p.Coefficients()
+		require.Len(t, got, len(tc.want))
+		for i, c := range got {
+			want, ok := new(big.Rat).SetString(tc.want[i])
+			require.True(t, ok)
+			require.Zero(t, want.Cmp(c), "coefficient %d: got %s, want %s", i, c.RatString(), tc.want[i])
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package maze
+
+import (
+	"fmt"
+	"slices"
+)
+
+// EnclosedArea returns the number of tiles enclosed by the loop, and the coordinates of those tiles.
+//
+// The count uses the shoelace formula for the area of the polygon through the center of each loop tile, then Pick's
+// theorem, A = i + b/2 - 1, to get the number of interior tiles i from the area A and the b loop tiles on the
+// boundary. The coordinates are found by scanning each row, flipping between outside and inside whenever the row
+// crosses a part of the loop that connects north.
+func (m Maze) EnclosedArea() (int, map[Coord]bool, error) {
+	l, err := m.Loop()
+	if err != nil {
+		return 0, nil, fmt.Errorf("m.Loop(): %w", err)
+	}
+	if l.Len() < 4 {
+		return 0, nil, fmt.Errorf("loop of %d pipes is too short to enclose anything", l.Len())
+	}
+
+	twiceArea := 0
+	for i, p := range l.Pipes {
+		a, b := p.loc, l.Pipes[(i+1)%l.Len()].loc
+		twiceArea += a.X*b.Y - b.X*a.Y
+	}
+	if twiceArea < 0 {
+		twiceArea = -twiceArea
+	}
+	count := (twiceArea - l.Len() + 2) / 2
+	return count, m.insideTiles(l), nil
+}
+
+// insideTiles scans each row for tiles inside the loop.
+func (m Maze) insideTiles(l Loop) map[Coord]bool {
+	onLoop := make(map[Coord]Pipe, l.Len())
+	for _, p := range l.Pipes {
+		onLoop[p.loc] = p
+	}
+
+	inside := map[Coord]bool{}
+	for y := 0; y < m.height; y++ {
+		in := false
+		for x := 0; x < m.width; x++ {
+			c := Coord{X: x, Y: y}
+			if p, ok := onLoop[c]; ok {
+				// Crossing |, L or J changes sides. F and 7 pair with a later J or L on the same row, which
+				// only flips once between them, so counting the north connections handles both cases.
+				if slices.Contains(p.directions, North) {
+					in = !in
+				}
+				continue
+			}
+			if in {
+				inside[c] = true
+			}
+		}
+	}
+	return inside
+}
+
+// direction returns the direction of the neighboring tile b from a.
+func direction(a, b Coord) Direction {
+	switch {
+	case b.Y < a.Y:
+		return North
+	case b.Y > a.Y:
+		return South
+	case b.X > a.X:
+		return East
+	}
+	return West
+}
@@ -0,0 +1,165 @@
+package maze_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	maze "github.com/harveysanders/advent-of-code-2023/day10-pipe-maze"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnclosedArea(t *testing.T) {
+	testCases := []struct {
+		name      string
+		input     string
+		startAs   byte // The real shape of the start tile, for the ray casting cross-check.
+		wantCount int
+	}{
+		{
+			name: "square",
+			input: `.....
+.S-7.
+.|.|.
+.L-J.
+.....
+`,
+			startAs:   'F',
+			wantCount: 1,
+		},
+		{
+			name: "squeezed between pipes",
+			input: `...........
+.S-------7.
+.|F-----7|.
+.||.....||.
+.||.....||.
+.|L-7.F-J|.
+.|..|.|..|.
+.L--J.L--J.
+...........
+`,
+			startAs:   'F',
+			wantCount: 4,
+		},
+		{
+			name: "larger",
+			input: `.F----7F7F7F7F-7....
+.|F--7||||||||FJ....
+.||.FJ||||||||L7....
+FJL7L7LJLJ||LJ.L-7..
+L--J.L7...LJS7F-7L7.
+....F-J..F7FJ|L7L7L7
+....L7.F7||L7|.L7L7|
+.....|FJLJ|FJ|F7|.LJ
+....FJL-7.||.||||...
+....L---J.LJ.LJLJ...
+`,
+			startAs:   'F',
+			wantCount: 8,
+		},
+		{
+			name: "junk pipes",
+			input: `FF7FSF7F7F7F7F7F---7
+L|LJ||||||||||||F--J
+FL-7LJLJ||||||LJL-77
+F--JF--7||LJLJ7F7FJ-
+L---JF-JLJ.||-FJLJJ7
+|F|F-JF---7F7-L7L|7|
+|FFJF7L7F-JF7|JL---7
+7-L-JL7||F7|L7F-7F7|
+L.L7LFJ|||||FJL7||LJ
+L7JLJL-JLJLJL--JLJ.L
+`,
+			startAs:   '7',
+			wantCount: 10,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := maze.ParseMaze(strings.NewReader(tc.input))
+			require.NoError(t, err)
+
+			gotCount, gotInside, err := m.EnclosedArea()
+			require.NoError(t, err)
+			require.Equal(t, tc.wantCount, gotCount)
+			require.Len(t, gotInside, gotCount)
+
+			grid := strings.Split(strings.TrimSpace(tc.input), "\n")
+			require.Equal(t, rayCastInside(grid, tc.startAs), gotInside)
+		})
+	}
+}
+
+// rayCastInside finds the tiles inside the loop through S by casting a ray diagonally down and to the right from each
+// tile and counting how many times it crosses the loop. The ray only grazes L and 7 corners, so they don't count.
+func rayCastInside(grid []string, startAs byte) map[maze.Coord]bool {
+	rows := make([][]byte, len(grid))
+	for y, line := range grid {
+		rows[y] = []byte(line)
+		if x := strings.IndexByte(line, 'S'); x >= 0 {
+			rows[y][x] = startAs
+		}
+	}
+
+	loop := loopTiles(grid, rows)
+	inside := map[maze.Coord]bool{}
+	for y := range rows {
+		for x := range rows[y] {
+			if loop[maze.Coord{X: x, Y: y}] {
+				continue
+			}
+			crossings := 0
+			for rx, ry := x+1, y+1; ry < len(rows) && rx < len(rows[ry]); rx, ry = rx+1, ry+1 {
+				c := rows[ry][rx]
+				if loop[maze.Coord{X: rx, Y: ry}] && c != 'L' && c != '7' {
+					crossings++
+				}
+			}
+			if crossings%2 == 1 {
+				inside[maze.Coord{X: x, Y: y}] = true
+			}
+		}
+	}
+	return inside
+}
+
+// loopTiles follows the pipes from S, using the start's real shape, to find every tile on the loop.
+func loopTiles(grid []string, rows [][]byte) map[maze.Coord]bool {
+	type offset struct{ dx, dy int }
+	connections := map[byte][]offset{
+		'|': {{0, -1}, {0, 1}},
+		'-': {{-1, 0}, {1, 0}},
+		'L': {{0, -1}, {1, 0}},
+		'J': {{0, -1}, {-1, 0}},
+		'7': {{0, 1}, {-1, 0}},
+		'F': {{0, 1}, {1, 0}},
+	}
+
+	var start maze.Coord
+	for y, line := range grid {
+		if x := strings.IndexByte(line, 'S'); x >= 0 {
+			start = maze.Coord{X: x, Y: y}
+		}
+	}
+
+	loop := map[maze.Coord]bool{start: true}
+	queue := []maze.Coord{start}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		for _, o := range connections[rows[c.Y][c.X]] {
+			next := maze.Coord{X: c.X + o.dx, Y: c.Y + o.dy}
+			if loop[next] {
+				continue
+			}
+			back := offset{-o.dx, -o.dy}
+			if slices.Contains(connections[rows[next.Y][next.X]], back) {
+				loop[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return loop
+}
@@ -0,0 +1,94 @@
+package maze
+
+import (
+	"errors"
+	"slices"
+)
+
+// Component is a group of pipes joined to each other, where both pipes of each join connect toward the other.
+type Component struct {
+	Tiles    []Coord // Tiles in the component, in reading order.
+	Closed   bool    // Every pipe connects to exactly two others, so the component is a closed loop.
+	HasStart bool    // The component includes the start tile.
+}
+
+// Components finds every group of joined pipes in the grid: closed loops, including the one through the start,
+// and dangling segments of junk pipe. If the loop through the start closes, the start is treated as its real
+// connector, so junk pipes next to it form their own components. If the loop doesn't close, the start keeps all four
+// directions and joins any neighbor that connects toward it, so its component is reported as not Closed.
+// Components are ordered by their first tile.
+func (m Maze) Components() []Component {
+	startLoc, err := m.FindStart()
+	hasStart := err == nil
+	if hasStart {
+		con, err := m.ResolveStart(false)
+		if err == nil || errors.Is(err, ErrAmbiguousStart) {
+			m.replaceStart(startLoc, con)
+		}
+	}
+
+	seen := map[Coord]bool{}
+	components := []Component{}
+	for y := 0; y < m.height; y++ {
+		for x := 0; x < m.width; x++ {
+			c := Coord{X: x, Y: y}
+			if seen[c] || !m.isPipe(c) {
+				continue
+			}
+
+			comp := Component{Tiles: []Coord{}, Closed: true}
+			seen[c] = true
+			queue := []Coord{c}
+			for len(queue) > 0 {
+				cur := queue[0]
+				queue = queue[1:]
+				comp.Tiles = append(comp.Tiles, cur)
+				if hasStart && cur == startLoc {
+					comp.HasStart = true
+				}
+
+				joined := m.joined(cur)
+				if len(joined) != 2 {
+					comp.Closed = false
+				}
+				for _, next := range joined {
+					if !seen[next] {
+						seen[next] = true
+						queue = append(queue, next)
+					}
+				}
+			}
+
+			slices.SortFunc(comp.Tiles, func(a, b Coord) int {
+				if a.Y != b.Y {
+					return a.Y - b.Y
+				}
+				return a.X - b.X
+			})
+			components = append(components, comp)
+		}
+	}
+	return components
+}
+
+// isPipe reports whether the tile holds a pipe or the start.
+func (m Maze) isPipe(c Coord) bool {
+	_, ok := conDirections[Connector(m.grid[c.Y][c.X])]
+	return ok
+}
+
+// joined returns the neighbors the pipe at c connects to, that also connect back to it.
+func (m Maze) joined(c Coord) []Coord {
+	p := NewPipe(string(m.grid[c.Y][c.X]), c.X, c.Y)
+	res := []Coord{}
+	for _, dir := range p.directions {
+		val, next, ok := m.Move(c, dir)
+		if !ok || val == "." {
+			continue
+		}
+		if NewPipe(val, next.X, next.Y).connects(dir) {
+			res = append(res, next)
+		}
+	}
+	return res
+}
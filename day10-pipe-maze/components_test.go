@@ -0,0 +1,173 @@
+package maze_test
+
+import (
+	"strings"
+	"testing"
+
+	maze "github.com/harveysanders/advent-of-code-2023/day10-pipe-maze"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponents(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		want  []maze.Component
+	}{
+		{
+			name: "loops and junk",
+			input: `S7.F7.-
+LJ.LJ.L
+..F-7..
+..|..|.
+`,
+			want: []maze.Component{
+				{
+					Tiles:    []maze.Coord{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}},
+					Closed:   true,
+					HasStart: true,
+				},
+				{
+					Tiles:  []maze.Coord{{X: 3, Y: 0}, {X: 4, Y: 0}, {X: 3, Y: 1}, {X: 4, Y: 1}},
+					Closed: true,
+				},
+				{Tiles: []maze.Coord{{X: 6, Y: 0}}},
+				{Tiles: []maze.Coord{{X: 6, Y: 1}}},
+				{Tiles: []maze.Coord{{X: 2, Y: 2}, {X: 3, Y: 2}, {X: 4, Y: 2}, {X: 2, Y: 3}}},
+				{Tiles: []maze.Coord{{X: 5, Y: 3}}},
+			},
+		},
+		{
+			// The | above the start connects to it, but isn't part of the loop.
+			name: "junk at start",
+			input: `.|...
+.S-7.
+.|.|.
+.L-J.
+.....
+`,
+			want: []maze.Component{
+				{Tiles: []maze.Coord{{X: 1, Y: 0}}},
+				{
+					Tiles: []maze.Coord{
+						{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 3, Y: 1},
+						{X: 1, Y: 2}, {X: 3, Y: 2},
+						{X: 1, Y: 3}, {X: 2, Y: 3}, {X: 3, Y: 3},
+					},
+					Closed:   true,
+					HasStart: true,
+				},
+			},
+		},
+		{
+			// The loop doesn't close, so the start joins every neighbor that connects toward it.
+			name:  "open loop",
+			input: "S-7\n..|\n...\n",
+			want: []maze.Component{
+				{
+					Tiles:    []maze.Coord{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}},
+					HasStart: true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := maze.ParseMaze(strings.NewReader(tc.input))
+			require.NoError(t, err)
+			require.Equal(t, tc.want, m.Components())
+		})
+	}
+}
+
+func TestComponentsJunk(t *testing.T) {
+	input := `7-F7-
+.FJ|7
+SJLL7
+|F--J
+LJ.LJ
+`
+	m, err := maze.ParseMaze(strings.NewReader(input))
+	require.NoError(t, err)
+
+	l, err := m.Loop()
+	require.NoError(t, err)
+
+	closed := 0
+	for _, c := range m.Components() {
+		if c.HasStart {
+			require.True(t, c.Closed)
+			require.Len(t, c.Tiles, l.Len())
+			for _, tile := range c.Tiles {
+				require.True(t, l.Contains(tile))
+			}
+		}
+		if c.Closed {
+			closed++
+		}
+	}
+	require.Equal(t, 1, closed)
+}
+
+func TestOpenLoop(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   string
+		wantMsg string
+	}{
+		{
+			name:    "no connecting neighbor",
+			input:   "...\n.S.\n...\n",
+			wantMsg: "no neighbor connects to the start",
+		},
+		{
+			name:    "dead end",
+			input:   "S-7\n..|\n...\n",
+			wantMsg: `pipe "|" at {X:2 Y:1} leads nowhere after 3 steps`,
+		},
+		{
+			name:    "off the edge",
+			input:   "S--\n|..\n",
+			wantMsg: "leads nowhere",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := maze.ParseMaze(strings.NewReader(tc.input))
+			require.NoError(t, err)
+
+			_, err = m.FarthestDistFromStart()
+			require.ErrorIs(t, err, maze.ErrOpenLoop)
+			require.ErrorContains(t, err, tc.wantMsg)
+
+			for _, c := range m.Components() {
+				if c.HasStart {
+					require.False(t, c.Closed)
+				}
+			}
+		})
+	}
+}
+
+func TestLoopJunkAtStart(t *testing.T) {
+	// The | above the start connects to it but leads nowhere, so the walk goes round the other way.
+	input := `.|...
+.S-7.
+.|.|.
+.L-J.
+.....
+`
+	m, err := maze.ParseMaze(strings.NewReader(input))
+	require.NoError(t, err)
+
+	dist, err := m.FarthestDistFromStart()
+	require.NoError(t, err)
+	require.Equal(t, 4, dist)
+
+	// Three neighbors connect to the start, but the loop still decides its connector.
+	con, err := m.ResolveStart(false)
+	require.ErrorIs(t, err, maze.ErrAmbiguousStart)
+	require.Equal(t, maze.ConnF, con)
+}
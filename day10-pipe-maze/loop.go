@@ -0,0 +1,70 @@
+package maze
+
+import "fmt"
+
+// Loop is the closed path of pipes through the start tile.
+type Loop struct {
+	Pipes    []Pipe        // Pipes in walking order, starting with the start tile as its real connector.
+	Dist     map[Coord]int // Steps from the start to each pipe, going whichever way round is shorter.
+	Farthest Coord         // The pipe farthest from the start. The first one in walking order if there's a tie.
+}
+
+// Loop walks the loop from the start tile.
+func (m Maze) Loop() (Loop, error) {
+	start, route, err := m.walkRoute()
+	if err != nil {
+		return Loop{}, err
+	}
+	if len(route) == 0 {
+		return Loop{}, fmt.Errorf("no loop through start at %+v", start.loc)
+	}
+
+	// The start's real shape connects to the first and last pipes of the route.
+	start.directions = []Direction{
+		direction(start.loc, route[0].loc),
+		direction(start.loc, route[len(route)-1].loc),
+	}
+	if con, ok := connectorFor(start.directions); ok {
+		start.con = con
+		start.directions = conDirections[con]
+	}
+
+	l := Loop{
+		Pipes: append([]Pipe{start}, route...),
+		Dist:  make(map[Coord]int, len(route)+1),
+	}
+	n := len(l.Pipes)
+	for i, p := range l.Pipes {
+		l.Dist[p.loc] = min(i, n-i)
+		if l.Dist[p.loc] > l.Dist[l.Farthest] || i == 0 {
+			l.Farthest = p.loc
+		}
+	}
+	return l, nil
+}
+
+// Len returns the number of pipes in the loop.
+func (l Loop) Len() int {
+	return len(l.Pipes)
+}
+
+// Contains reports whether the tile is part of the loop.
+func (l Loop) Contains(c Coord) bool {
+	_, ok := l.Dist[c]
+	return ok
+}
+
+// Loc returns the pipe's location in the grid.
+func (p Pipe) Loc() Coord {
+	return p.loc
+}
+
+// Connector returns the pipe's shape.
+func (p Pipe) Connector() Connector {
+	return p.con
+}
+
+// Directions returns the directions the pipe connects.
+func (p Pipe) Directions() []Direction {
+	return append([]Direction{}, p.directions...)
+}
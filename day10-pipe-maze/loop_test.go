@@ -0,0 +1,57 @@
+package maze_test
+
+import (
+	"strings"
+	"testing"
+
+	maze "github.com/harveysanders/advent-of-code-2023/day10-pipe-maze"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoop(t *testing.T) {
+	input := `..F7.
+.FJ|.
+SJ.L7
+|F--J
+LJ...
+`
+	// Distance from the start for each tile on the loop, from the puzzle description.
+	wantDists := `..45.
+.236.
+01.78
+14567
+23...`
+
+	m, err := maze.ParseMaze(strings.NewReader(input))
+	require.NoError(t, err)
+
+	l, err := m.Loop()
+	require.NoError(t, err)
+	require.Equal(t, 16, l.Len())
+	require.Equal(t, maze.Coord{X: 4, Y: 2}, l.Farthest)
+	require.Equal(t, 8, l.Dist[l.Farthest])
+
+	start := l.Pipes[0]
+	require.Equal(t, maze.Coord{X: 0, Y: 2}, start.Loc())
+	require.Equal(t, maze.ConnF, start.Connector())
+	require.ElementsMatch(t, []maze.Direction{maze.East, maze.South}, start.Directions())
+
+	for y, row := range strings.Split(wantDists, "\n") {
+		for x, r := range row {
+			c := maze.Coord{X: x, Y: y}
+			if r == '.' {
+				require.False(t, l.Contains(c), "%+v", c)
+				continue
+			}
+			require.True(t, l.Contains(c), "%+v", c)
+			require.Equal(t, int(r-'0'), l.Dist[c], "%+v", c)
+		}
+	}
+
+	// Each pipe is next to the one before it.
+	for i, p := range l.Pipes {
+		next := l.Pipes[(i+1)%l.Len()].Loc()
+		dx, dy := next.X-p.Loc().X, next.Y-p.Loc().Y
+		require.Equal(t, 1, dx*dx+dy*dy, "pipe %d at %+v", i, p.Loc())
+	}
+}
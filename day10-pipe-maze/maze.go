@@ -2,18 +2,21 @@ package maze
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
-	"math"
 	"slices"
 	"strings"
 )
 
+// ErrOpenLoop is returned when the walk from the start can't get back to the start.
+var ErrOpenLoop = errors.New("loop doesn't close")
+
 type Maze struct {
 	grid   []string
 	height int
 	width  int
-	route  []Pipe
+	start  *Coord // Location of the start once the grid's "S" has been replaced by its real connector.
 }
 
 type Coord struct {
@@ -27,16 +30,18 @@ type Pipe struct {
 	loc        Coord
 }
 
+// conDirections lists the directions each connector joins.
+var conDirections = map[Connector][]Direction{
+	ConnVertical:   {North, South},
+	ConnHorizontal: {East, West},
+	ConnL:          {North, East},
+	ConnJ:          {North, West},
+	Conn7:          {South, West},
+	ConnF:          {East, South},
+	ConnStart:      {North, East, South, West},
+}
+
 func NewPipe(label string, x, y int) Pipe {
-	conDirections := map[Connector][]Direction{
-		ConnVertical:   {North, South},
-		ConnHorizontal: {East, West},
-		ConnL:          {North, East},
-		ConnJ:          {North, West},
-		Conn7:          {South, West},
-		ConnF:          {East, South},
-		ConnStart:      {North, East, South, West},
-	}
 	p := Pipe{
 		con: Connector(label),
 		loc: Coord{X: x, Y: y},
@@ -68,8 +73,7 @@ const (
 
 func ParseMaze(r io.Reader) (Maze, error) {
 	maze := Maze{
-		grid:  make([]string, 0),
-		route: make([]Pipe, 0),
+		grid: make([]string, 0),
 	}
 	scr := bufio.NewScanner(r)
 	for scr.Scan() {
@@ -89,6 +93,9 @@ func ParseMaze(r io.Reader) (Maze, error) {
 }
 
 func (m Maze) FindStart() (Coord, error) {
+	if m.start != nil {
+		return *m.start, nil
+	}
 	startChar := "S"
 	for y, line := range m.grid {
 		x := strings.Index(line, startChar)
@@ -99,19 +106,56 @@ func (m Maze) FindStart() (Coord, error) {
 	return Coord{}, fmt.Errorf("start %q not found", startChar)
 }
 
+// FarthestDistFromStart returns the number of steps along the loop to the pipe farthest from the start.
 func (m Maze) FarthestDistFromStart() (int, error) {
+	l, err := m.Loop()
+	if err != nil {
+		return 0, fmt.Errorf("m.Loop(): %w", err)
+	}
+	return l.Dist[l.Farthest], nil
+}
+
+// walkRoute follows the loop from the start until it gets back to the start. It returns the start pipe and the
+// route of pipes after it, in order, not including the start. Junk pipes can also connect to the start, so each
+// direction out of the start is tried in turn. The returned error wraps ErrOpenLoop if none of them lead back.
+func (m Maze) walkRoute() (Pipe, []Pipe, error) {
 	startLoc, err := m.FindStart()
 	if err != nil {
-		return 0, fmt.Errorf("m.FindStart(): %w", err)
+		return Pipe{}, []Pipe{}, fmt.Errorf("m.FindStart(): %w", err)
 	}
 
 	startLabel := string(m.grid[startLoc.Y][startLoc.X])
 	start := NewPipe(startLabel, startLoc.X, startLoc.Y)
-	curPipe := start
-	isStart := true
-	var fromDir Direction
-	for isStart || startLabel != string(curPipe.con) {
-		isStart = false
+	errs := []error{}
+	for _, dir := range start.directions {
+		val, pos, ok := m.Move(start.loc, dir)
+		if !ok || val == "." || !NewPipe(val, pos.X, pos.Y).connects(dir) {
+			continue
+		}
+		route, err := m.walkFrom(start, dir)
+		if err == nil {
+			return start, route, nil
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return start, []Pipe{}, fmt.Errorf("%w: no neighbor connects to the start at %+v", ErrOpenLoop, startLoc)
+	}
+	return start, []Pipe{}, errors.Join(errs...)
+}
+
+// walkFrom follows the pipes from the start, leaving in the given direction, until it gets back to the start.
+// The pipe in that direction must connect back to the start.
+func (m Maze) walkFrom(start Pipe, dir Direction) ([]Pipe, error) {
+	route := make([]Pipe, 0)
+	val, pos, _ := m.Move(start.loc, dir)
+	curPipe := NewPipe(val, pos.X, pos.Y)
+	// Where we came from, ex: if we just moved to the the east (dir), we came from the west.
+	fromDir := dir.complement()
+
+	for curPipe.loc != start.loc {
+		route = append(route, curPipe)
+		moved := false
 		for _, nextDir := range curPipe.directions {
 			if nextDir == fromDir {
 				// Keep looking if we're facing the direction we just came from.
@@ -124,24 +168,23 @@ func (m Maze) FarthestDistFromStart() (int, error) {
 				}
 				next := NewPipe(nextVal, nextPos.X, nextPos.Y)
 				if next.connects(nextDir) {
-					if next.con != ConnStart {
-						m.route = append(m.route, next)
-					}
 					curPipe = next
-					// Where we came from, ex: if we just moved to the the east (nextDir), we came from the west.
 					fromDir = nextDir.complement()
+					moved = true
 					break
 				}
 			}
 		}
+		if !moved {
+			return route, fmt.Errorf("%w: pipe %q at %+v leads nowhere after %d steps %s from the start at %+v",
+				ErrOpenLoop, curPipe.con, curPipe.loc, len(route), dir, start.loc)
+		}
+		if len(route) > m.width*m.height {
+			return route, fmt.Errorf("%w: walked %d steps %s from the start at %+v without getting back",
+				ErrOpenLoop, len(route), dir, start.loc)
+		}
 	}
-
-	dist := 0
-	if len(m.route) > 0 {
-		// Include +1 back to start step
-		dist = int(math.Ceil(float64(len(m.route)+1) / 2))
-	}
-	return dist, nil
+	return route, nil
 }
 
 func (m Maze) Move(loc Coord, dir Direction) (val string, next Coord, ok bool) {
@@ -196,6 +239,20 @@ func (p Pipe) connects(from Direction) bool {
 	return slices.Contains(p.directions, oppDirection)
 }
 
+func (d Direction) String() string {
+	switch d {
+	case North:
+		return "north"
+	case South:
+		return "south"
+	case East:
+		return "east"
+	case West:
+		return "west"
+	}
+	return fmt.Sprintf("Direction(%d)", int(d))
+}
+
 func (d Direction) complement() Direction {
 	c := map[Direction]Direction{
 		North: South,
@@ -0,0 +1,91 @@
+package maze
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// RenderOptions controls how Render draws the maze. The zero value draws plain box-drawing characters.
+type RenderOptions struct {
+	DimOutside    bool // Dim every tile that isn't part of the loop.
+	HighlightLoop bool // Draw the loop in bold yellow.
+	MarkEnclosed  bool // Draw tiles enclosed by the loop as green dots.
+	Distance      bool // Color the loop by distance from the start, blue to red. Takes precedence over HighlightLoop.
+}
+
+const (
+	ansiReset     = "\x1b[0m"
+	ansiDim       = "\x1b[2m"
+	ansiHighlight = "\x1b[1;33m"
+	ansiEnclosed  = "\x1b[32m"
+)
+
+// boxDrawing maps each connector to its box-drawing character.
+var boxDrawing = map[Connector]rune{
+	ConnVertical:   '│',
+	ConnHorizontal: '─',
+	ConnL:          '└',
+	ConnJ:          '┘',
+	Conn7:          '┐',
+	ConnF:          '┌',
+}
+
+// distanceColors is a blue to red ramp from the ANSI 256-color palette.
+var distanceColors = []int{21, 27, 33, 39, 45, 51, 50, 49, 48, 47, 46, 82, 118, 154, 190, 226, 220, 214, 208, 202, 196}
+
+// Render writes the grid to w using box-drawing characters, with the start drawn as its real connector.
+// The options add ANSI colors for debugging the loop.
+func (m Maze) Render(w io.Writer, opts RenderOptions) error {
+	needsLoop := opts.DimOutside || opts.HighlightLoop || opts.MarkEnclosed || opts.Distance
+	l, err := m.Loop()
+	if err != nil && needsLoop {
+		return fmt.Errorf("m.Loop(): %w", err)
+	}
+	startLoc, _ := m.FindStart()
+
+	inside := map[Coord]bool{}
+	if opts.MarkEnclosed {
+		_, inside, err = m.EnclosedArea()
+		if err != nil {
+			return fmt.Errorf("m.EnclosedArea(): %w", err)
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	for y, line := range m.grid {
+		for x, r := range line {
+			c := Coord{X: x, Y: y}
+			char := r
+			if box, ok := boxDrawing[Connector(r)]; ok {
+				char = box
+			}
+			if c == startLoc && l.Len() > 0 {
+				char = boxDrawing[l.Pipes[0].con]
+			}
+
+			style := ""
+			switch {
+			case l.Contains(c) && opts.Distance:
+				maxDist := max(l.Dist[l.Farthest], 1)
+				color := distanceColors[l.Dist[c]*(len(distanceColors)-1)/maxDist]
+				style = fmt.Sprintf("\x1b[38;5;%dm", color)
+			case l.Contains(c) && opts.HighlightLoop:
+				style = ansiHighlight
+			case inside[c]:
+				char = '•'
+				style = ansiEnclosed
+			case !l.Contains(c) && opts.DimOutside:
+				style = ansiDim
+			}
+
+			if style == "" {
+				bw.WriteRune(char)
+				continue
+			}
+			fmt.Fprintf(bw, "%s%c%s", style, char, ansiReset)
+		}
+		bw.WriteString("\n")
+	}
+	return bw.Flush()
+}
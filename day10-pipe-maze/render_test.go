@@ -0,0 +1,63 @@
+package maze_test
+
+import (
+	"strings"
+	"testing"
+
+	maze "github.com/harveysanders/advent-of-code-2023/day10-pipe-maze"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	input := `.....
+.S-7.
+.|.|.
+.L-J.
+.....
+`
+	m, err := maze.ParseMaze(strings.NewReader(input))
+	require.NoError(t, err)
+
+	var b strings.Builder
+	err = m.Render(&b, maze.RenderOptions{})
+	require.NoError(t, err)
+	require.Equal(t, `.....
+.┌─┐.
+.│.│.
+.└─┘.
+.....
+`, b.String())
+
+	b.Reset()
+	err = m.Render(&b, maze.RenderOptions{HighlightLoop: true, MarkEnclosed: true, DimOutside: true})
+	require.NoError(t, err)
+	lines := strings.Split(b.String(), "\n")
+	require.Equal(t, "\x1b[2m.\x1b[0m\x1b[1;33m│\x1b[0m\x1b[32m•\x1b[0m\x1b[1;33m│\x1b[0m\x1b[2m.\x1b[0m", lines[2])
+
+	b.Reset()
+	err = m.Render(&b, maze.RenderOptions{Distance: true})
+	require.NoError(t, err)
+	lines = strings.Split(b.String(), "\n")
+	// The start is blue and the farthest tile, opposite it, is red.
+	require.Contains(t, lines[1], "\x1b[38;5;21m┌")
+	require.Contains(t, lines[3], "\x1b[38;5;196m┘")
+}
+
+func TestRenderJunk(t *testing.T) {
+	// Junk pipes are drawn, but dimmed, and the start is drawn as its real shape.
+	input := `7-F7-
+.FJ|7
+SJLL7
+|F--J
+LJ.LJ
+`
+	m, err := maze.ParseMaze(strings.NewReader(input))
+	require.NoError(t, err)
+
+	var b strings.Builder
+	err = m.Render(&b, maze.RenderOptions{DimOutside: true})
+	require.NoError(t, err)
+	lines := strings.Split(b.String(), "\n")
+	require.True(t, strings.HasPrefix(lines[0], "\x1b[2m┐\x1b[0m"), lines[0])
+	require.True(t, strings.HasPrefix(lines[2], "┌┘"), lines[2])
+}
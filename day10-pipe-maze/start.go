@@ -0,0 +1,68 @@
+package maze
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrAmbiguousStart is returned when more than two neighbors connect to the start, so some of them are junk.
+var ErrAmbiguousStart = errors.New("more than two neighbors connect to the start")
+
+// ResolveStart finds the connector hidden under the start tile from the two pipes the loop leaves and enters it by.
+// It returns an error if no neighbor connects to the start or the loop doesn't close. If more than two neighbors
+// connect, the loop's connector is still returned, with an error wrapping ErrAmbiguousStart.
+// If rewrite is true and there's no error, the start tile in the grid is replaced by the connector; FindStart still
+// returns its location.
+func (m *Maze) ResolveStart(rewrite bool) (Connector, error) {
+	loc, err := m.FindStart()
+	if err != nil {
+		return "", fmt.Errorf("m.FindStart(): %w", err)
+	}
+
+	connecting := 0
+	for _, dir := range []Direction{North, South, East, West} {
+		val, next, ok := m.Move(loc, dir)
+		if ok && val != "." && NewPipe(val, next.X, next.Y).connects(dir) {
+			connecting++
+		}
+	}
+	if connecting == 0 {
+		return "", fmt.Errorf("start at %+v: no neighbors connect to it, want 2", loc)
+	}
+
+	l, err := m.Loop()
+	if err != nil {
+		return "", fmt.Errorf("m.Loop(): %w", err)
+	}
+	con := l.Pipes[0].con
+	if connecting > 2 {
+		return con, fmt.Errorf("%w: start at %+v has %d, want 2", ErrAmbiguousStart, loc, connecting)
+	}
+
+	if rewrite {
+		m.replaceStart(loc, con)
+	}
+	return con, nil
+}
+
+// replaceStart replaces the start tile in the grid with the connector. It copies the grid so other copies of the
+// maze keep their start tile.
+func (m *Maze) replaceStart(loc Coord, con Connector) {
+	m.grid = slices.Clone(m.grid)
+	row := []byte(m.grid[loc.Y])
+	row[loc.X] = con[0]
+	m.grid[loc.Y] = string(row)
+	m.start = &loc
+}
+
+// connectorFor returns the connector that joins exactly the two directions.
+func connectorFor(dirs []Direction) (Connector, bool) {
+	for _, con := range []Connector{ConnVertical, ConnHorizontal, ConnL, ConnJ, Conn7, ConnF} {
+		want := conDirections[con]
+		if len(dirs) == len(want) && slices.Contains(want, dirs[0]) && slices.Contains(want, dirs[1]) {
+			return con, true
+		}
+	}
+	return "", false
+}
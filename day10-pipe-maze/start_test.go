@@ -0,0 +1,117 @@
+package maze_test
+
+import (
+	"strings"
+	"testing"
+
+	maze "github.com/harveysanders/advent-of-code-2023/day10-pipe-maze"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveStart(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   string
+		want    maze.Connector
+		wantErr bool
+	}{
+		{
+			name: "surrounded by pipes",
+			input: `-L|F7
+7S-7|
+L|7||
+-L-J|
+L|-JF
+`,
+			want: maze.ConnF,
+		},
+		{
+			name: "on the edge",
+			input: `7-F7-
+.FJ|7
+SJLL7
+|F--J
+LJ.LJ
+`,
+			want: maze.ConnF,
+		},
+		{
+			name: "vertical",
+			input: `F-7
+|.|
+S.|
+|.|
+L-J
+`,
+			want: maze.ConnVertical,
+		},
+		{
+			name:    "no neighbors connect",
+			input:   "...\n.S.\n...\n",
+			wantErr: true,
+		},
+		{
+			name:    "one neighbor connects",
+			input:   "...\n.S-\n...\n",
+			wantErr: true,
+		},
+		{
+			name:    "three neighbors connect",
+			input:   ".|.\n-S-\n...\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := maze.ParseMaze(strings.NewReader(tc.input))
+			require.NoError(t, err)
+
+			got, err := m.ResolveStart(false)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestResolveStartRewrite(t *testing.T) {
+	input := `.....
+.S-7.
+.|.|.
+.L-J.
+.....
+`
+	m, err := maze.ParseMaze(strings.NewReader(input))
+	require.NoError(t, err)
+	original := m
+
+	got, err := m.ResolveStart(true)
+	require.NoError(t, err)
+	require.Equal(t, maze.ConnF, got)
+
+	start, err := m.FindStart()
+	require.NoError(t, err)
+	require.Equal(t, maze.Coord{X: 1, Y: 1}, start)
+
+	val, _, ok := m.Move(maze.Coord{X: 1, Y: 2}, maze.North)
+	require.True(t, ok)
+	require.Equal(t, "F", val)
+
+	// The loop can still be walked without the S.
+	dist, err := m.FarthestDistFromStart()
+	require.NoError(t, err)
+	require.Equal(t, 4, dist)
+
+	count, _, err := m.EnclosedArea()
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	// The rewrite doesn't affect copies of the maze.
+	val, _, ok = original.Move(maze.Coord{X: 1, Y: 2}, maze.North)
+	require.True(t, ok)
+	require.Equal(t, "S", val)
+}